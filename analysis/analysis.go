@@ -0,0 +1,183 @@
+// Package analysis measures the perceptual properties of colorgrad gradients.
+package analysis
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mazznoer/colorgrad"
+)
+
+type Metric int
+
+const (
+	// CIEDE2000 difference in CIE L*a*b*
+	MetricCIEDE2000 Metric = iota
+	// Euclidean distance in Oklab
+	MetricOklab
+)
+
+func (m Metric) String() string {
+	switch m {
+	case MetricCIEDE2000:
+		return "MetricCIEDE2000"
+	case MetricOklab:
+		return "MetricOklab"
+	}
+	return ""
+}
+
+// Color difference between a and b
+func (m Metric) Distance(a, b colorgrad.Color) float64 {
+	if m == MetricOklab {
+		return colorgrad.DeltaEOk(a, b)
+	}
+	return colorgrad.DeltaE2000(a, b)
+}
+
+type Options struct {
+	// Number of samples evenly spaced across the domain. Default is 256.
+	Samples int
+	// Color difference of the steps and the length. Default is CIEDE2000.
+	Metric Metric
+	// Relative deviation from the mean step above which a step is non-uniform,
+	// e.g. 0.25 for 25%. Default is 0.25.
+	Tolerance float64
+	// Maximum number of regions in Report.Worst. Default is 5.
+	Regions int
+}
+
+// Run of consecutive non-uniform steps
+type Region struct {
+	// Positions in the gradient domain
+	Start, End float64
+	// Step of the largest deviation, relative to the mean step: above 1 the
+	// colors change too fast, below 1 too slow
+	Step float64
+}
+
+// Deviation of the region from a uniform step
+func (r Region) Deviation() float64 {
+	return math.Abs(r.Step - 1)
+}
+
+type Report struct {
+	Metric Metric
+	// Sample positions in the gradient domain
+	Positions []float64
+	// CIE L* of each sample, in [0..100]
+	Lightness []float64
+	// Color difference between consecutive samples, one less than Positions
+	Steps []float64
+	// Sum of the steps
+	Length float64
+	// Mean, smallest and largest step
+	MeanStep, MinStep, MaxStep float64
+	// Coefficient of variation of the steps, 0 for a perceptually uniform
+	// gradient
+	Variation float64
+	// L* never decreases, or never increases, along the domain. Changes
+	// smaller than 1e-6 are ignored.
+	Increasing, Decreasing bool
+	// Positions where L* changes direction
+	Reversals []float64
+	// Non-uniform regions, the largest deviation first
+	Worst []Region
+}
+
+// L* is monotonic along the domain
+func (r Report) Monotonic() bool {
+	return r.Increasing || r.Decreasing
+}
+
+// Sample g evenly across its domain and measure its perceptual uniformity.
+// Alpha is ignored.
+func Analyze(g colorgrad.Gradient, opts Options) Report {
+	if opts.Samples < 2 {
+		opts.Samples = 256
+	}
+	if opts.Tolerance <= 0 {
+		opts.Tolerance = 0.25
+	}
+	if opts.Regions < 1 {
+		opts.Regions = 5
+	}
+
+	n := opts.Samples
+	dmin, dmax := g.Domain()
+	r := Report{
+		Metric:    opts.Metric,
+		Positions: make([]float64, n),
+		Lightness: make([]float64, n),
+		Steps:     make([]float64, n-1),
+		MinStep:   math.Inf(1),
+	}
+
+	colors := make([]colorgrad.Color, n)
+	for i := range colors {
+		t := dmin + (dmax-dmin)*float64(i)/float64(n-1)
+		// Rounding may overshoot the end of the domain
+		if i == n-1 {
+			t = dmax
+		}
+		colors[i] = g.At(t)
+		r.Positions[i] = t
+		r.Lightness[i], _, _ = colorgrad.ToLab(colors[i])
+	}
+
+	for i := range r.Steps {
+		d := opts.Metric.Distance(colors[i], colors[i+1])
+		r.Steps[i] = d
+		r.Length += d
+		r.MinStep = math.Min(r.MinStep, d)
+		r.MaxStep = math.Max(r.MaxStep, d)
+	}
+	r.MeanStep = r.Length / float64(len(r.Steps))
+
+	if r.MeanStep > 0 {
+		sum := 0.0
+		for _, d := range r.Steps {
+			sum += (d - r.MeanStep) * (d - r.MeanStep)
+		}
+		r.Variation = math.Sqrt(sum/float64(len(r.Steps))) / r.MeanStep
+	}
+
+	r.Increasing, r.Decreasing, r.Reversals = lightnessDirection(r.Positions, r.Lightness)
+
+	r.Worst = worstRegions(r, opts.Tolerance, opts.Regions)
+	return r
+}
+
+func worstRegions(r Report, tolerance float64, limit int) []Region {
+	regions := []Region{}
+	if r.MeanStep == 0 {
+		return regions
+	}
+
+	cur := -1
+	for i, d := range r.Steps {
+		rel := d / r.MeanStep
+		if math.Abs(rel-1) <= tolerance {
+			cur = -1
+			continue
+		}
+		// A region doesn't mix steps too fast and too slow
+		if cur >= 0 && (rel > 1) == (regions[cur].Step > 1) {
+			regions[cur].End = r.Positions[i+1]
+			if math.Abs(rel-1) > regions[cur].Deviation() {
+				regions[cur].Step = rel
+			}
+			continue
+		}
+		regions = append(regions, Region{r.Positions[i], r.Positions[i+1], rel})
+		cur = len(regions) - 1
+	}
+
+	sort.SliceStable(regions, func(i, j int) bool {
+		return regions[i].Deviation() > regions[j].Deviation()
+	})
+	if len(regions) > limit {
+		regions = regions[:limit]
+	}
+	return regions
+}
@@ -0,0 +1,106 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mazznoer/colorgrad"
+)
+
+func testNear(t *testing.T, a, b, tolerance float64) {
+	t.Helper()
+	if math.Abs(a-b) > tolerance {
+		t.Errorf("left: %v, right: %v", a, b)
+	}
+}
+
+func testTrue(t *testing.T, b bool) {
+	t.Helper()
+	if !b {
+		t.Errorf("it false")
+	}
+}
+
+func Test_Analyze(t *testing.T) {
+	grad, _ := colorgrad.NewGradient().
+		HtmlColors("#000", "#fff").
+		Domain(0, 10).
+		Mode(colorgrad.BlendLab).
+		Build()
+
+	r := Analyze(grad, Options{Samples: 11})
+	testTrue(t, r.Metric == MetricCIEDE2000)
+	testTrue(t, len(r.Positions) == 11 && len(r.Lightness) == 11 && len(r.Steps) == 10)
+	testNear(t, r.Positions[0], 0, 1e-9)
+	testNear(t, r.Positions[10], 10, 1e-9)
+	testNear(t, r.Lightness[0], 0, 1e-3)
+	testNear(t, r.Lightness[5], 50, 1e-3)
+	testNear(t, r.Lightness[10], 100, 1e-3)
+	testTrue(t, r.Increasing && !r.Decreasing && r.Monotonic())
+	testTrue(t, len(r.Reversals) == 0)
+	testNear(t, r.Length, r.MeanStep*10, 1e-9)
+	testTrue(t, r.MinStep <= r.MeanStep && r.MeanStep <= r.MaxStep)
+	// CIEDE2000 weighs lightness differences near L* 50 less
+	testTrue(t, r.MinStep < r.MaxStep && r.Variation > 0)
+
+	// Blended in Oklab, equal steps in Oklab
+	grad, _ = colorgrad.NewGradient().
+		HtmlColors("#000", "#fff").
+		Mode(colorgrad.BlendOklab).
+		Build()
+
+	r = Analyze(grad, Options{Metric: MetricOklab})
+	testTrue(t, len(r.Positions) == 256)
+	testNear(t, r.Length, 1, 1e-3)
+	testNear(t, r.Variation, 0, 1e-3)
+	testTrue(t, len(r.Worst) == 0)
+}
+
+func Test_Lightness(t *testing.T) {
+	grad, _ := colorgrad.NewGradient().
+		HtmlColors("#fff", "#000", "#fff").
+		Build()
+
+	r := Analyze(grad, Options{Samples: 21})
+	testTrue(t, !r.Increasing && !r.Decreasing && !r.Monotonic())
+	testTrue(t, len(r.Reversals) == 1)
+	testNear(t, r.Reversals[0], 0.5, 1e-9)
+
+	r = Analyze(colorgrad.Greys(), Options{})
+	testTrue(t, r.Decreasing && !r.Increasing)
+
+	grad, _ = colorgrad.NewGradient().HtmlColors("#f00", "#f00").Build()
+	r = Analyze(grad, Options{Samples: 5})
+	testTrue(t, r.Increasing && r.Decreasing)
+	testTrue(t, r.Length == 0 && r.Variation == 0)
+	testTrue(t, len(r.Worst) == 0)
+}
+
+func Test_WorstRegions(t *testing.T) {
+	// Half of the lightness in the first tenth of the domain
+	grad, _ := colorgrad.NewGradient().
+		HtmlColors("#000", "#777", "#fff").
+		Domain(0, 0.1, 1).
+		Mode(colorgrad.BlendOklab).
+		Build()
+
+	r := Analyze(grad, Options{Samples: 101, Metric: MetricOklab})
+	testTrue(t, len(r.Worst) == 2)
+
+	fast := r.Worst[0]
+	testNear(t, fast.Start, 0, 1e-9)
+	testNear(t, fast.End, 0.1, 1e-9)
+	testTrue(t, fast.Step > 3)
+	testNear(t, fast.Deviation(), fast.Step-1, 1e-9)
+
+	slow := r.Worst[1]
+	testNear(t, slow.Start, 0.1, 1e-9)
+	testNear(t, slow.End, 1, 1e-9)
+	testTrue(t, slow.Step < 1)
+
+	r = Analyze(grad, Options{Samples: 101, Metric: MetricOklab, Regions: 1})
+	testTrue(t, len(r.Worst) == 1 && r.Worst[0] == fast)
+
+	r = Analyze(grad, Options{Samples: 101, Metric: MetricOklab, Tolerance: 10})
+	testTrue(t, len(r.Worst) == 0)
+}
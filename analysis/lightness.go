@@ -0,0 +1,102 @@
+package analysis
+
+import (
+	"math"
+
+	"github.com/mazznoer/colorgrad"
+)
+
+type LightnessOptions struct {
+	// Number of samples evenly spaced across the domain. Default is 256.
+	Samples int
+	// CIE L* or Oklab L. Default is CIE L*.
+	Scale colorgrad.LightnessScale
+}
+
+type LightnessReport struct {
+	Scale colorgrad.LightnessScale
+	// Sample positions in the gradient domain
+	Positions []float64
+	// Lightness of each sample
+	Lightness []float64
+	// Smallest and largest lightness
+	Min, Max float64
+	// Lightness never decreases, or never increases, along the domain.
+	// Changes smaller than 1e-6 are ignored.
+	Increasing, Decreasing bool
+	// Positions where the lightness changes direction
+	Reversals []float64
+}
+
+// Lightness is monotonic along the domain, so the gradient can be read in
+// grayscale
+func (r LightnessReport) Monotonic() bool {
+	return r.Increasing || r.Decreasing
+}
+
+// Difference between the largest and smallest lightness
+func (r LightnessReport) Range() float64 {
+	return r.Max - r.Min
+}
+
+// Sample g evenly across its domain and check whether its lightness is
+// monotonic, e.g. so it stays readable when printed in black and white.
+// Alpha is ignored.
+func CheckLightness(g colorgrad.Gradient, opts LightnessOptions) LightnessReport {
+	if opts.Samples < 2 {
+		opts.Samples = 256
+	}
+
+	n := opts.Samples
+	dmin, dmax := g.Domain()
+	r := LightnessReport{
+		Scale:     opts.Scale,
+		Positions: make([]float64, n),
+		Lightness: make([]float64, n),
+		Min:       math.Inf(1),
+		Max:       math.Inf(-1),
+	}
+
+	for i := range r.Positions {
+		t := dmin + (dmax-dmin)*float64(i)/float64(n-1)
+		// Rounding may overshoot the end of the domain
+		if i == n-1 {
+			t = dmax
+		}
+		l := opts.Scale.Lightness(g.At(t))
+		r.Positions[i] = t
+		r.Lightness[i] = l
+		r.Min = math.Min(r.Min, l)
+		r.Max = math.Max(r.Max, l)
+	}
+
+	r.Increasing, r.Decreasing, r.Reversals = lightnessDirection(r.Positions, r.Lightness)
+	return r
+}
+
+const lightnessEpsilon = 1e-6
+
+// Whether the lightness never decreases or never increases, and the positions
+// where it changes direction
+func lightnessDirection(positions, lightness []float64) (increasing, decreasing bool, reversals []float64) {
+	increasing, decreasing = true, true
+	dir := 0
+	for i := 1; i < len(lightness); i++ {
+		dl := lightness[i] - lightness[i-1]
+		if math.Abs(dl) <= lightnessEpsilon {
+			continue
+		}
+		d := 1
+		if dl < 0 {
+			d = -1
+			increasing = false
+		} else {
+			decreasing = false
+		}
+		if dir != 0 && d != dir {
+			reversals = append(reversals, positions[i-1])
+		}
+		dir = d
+	}
+	return increasing, decreasing, reversals
+}
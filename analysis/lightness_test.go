@@ -0,0 +1,54 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/mazznoer/colorgrad"
+)
+
+func Test_CheckLightness(t *testing.T) {
+	r := CheckLightness(colorgrad.Viridis(), LightnessOptions{})
+	testTrue(t, len(r.Positions) == 256 && len(r.Lightness) == 256)
+	testTrue(t, r.Scale == colorgrad.LightnessLab)
+	testTrue(t, r.Increasing && !r.Decreasing && r.Monotonic())
+	testTrue(t, len(r.Reversals) == 0)
+	testNear(t, r.Min, r.Lightness[0], 1e-9)
+	testNear(t, r.Max, r.Lightness[255], 1e-9)
+	testTrue(t, r.Range() > 70)
+
+	grad, _ := colorgrad.NewGradient().
+		HtmlColors("#000", "#fff").
+		Domain(-5.5, -3.857142857142857).
+		OverColor(colorgrad.Rgb(1, 0, 0, 1)).
+		Build()
+	r = CheckLightness(grad, LightnessOptions{Samples: 4})
+	testTrue(t, r.Positions[3] == -3.857142857142857)
+	testNear(t, r.Max, 100, 1e-3)
+	ar := Analyze(grad, Options{Samples: 4})
+	testTrue(t, ar.Positions[3] == -3.857142857142857)
+	testNear(t, ar.Lightness[3], 100, 1e-3)
+
+	r = CheckLightness(colorgrad.Greys(), LightnessOptions{Samples: 11, Scale: colorgrad.LightnessOklab})
+	testTrue(t, r.Decreasing && !r.Increasing)
+	testTrue(t, r.Max <= 1 && r.Range() > 0.5)
+
+	grad, _ = colorgrad.NewGradient().
+		HtmlColors("#000", "#fff", "#000", "#fff").
+		Domain(0, 3).
+		Build()
+	r = CheckLightness(grad, LightnessOptions{Samples: 31})
+	testTrue(t, !r.Monotonic())
+	testTrue(t, len(r.Reversals) == 2)
+	testNear(t, r.Reversals[0], 1, 1e-9)
+	testNear(t, r.Reversals[1], 2, 1e-9)
+	testNear(t, r.Range(), 100, 1e-3)
+
+	// The grayscale rendering has the same lightness
+	jet := colorgrad.Jet()
+	a := CheckLightness(jet, LightnessOptions{Samples: 21})
+	b := CheckLightness(jet.Grayscale(colorgrad.LightnessLab), LightnessOptions{Samples: 21})
+	testTrue(t, !a.Monotonic() && !b.Monotonic())
+	for i := range a.Lightness {
+		testNear(t, a.Lightness[i], b.Lightness[i], 1e-3)
+	}
+}
@@ -29,23 +29,15 @@ func (lg basisGradient) At(t float64) Color {
 		return Color{A: 1}
 	}
 
-	low := 0
-	high := len(lg.positions)
-	n := high - 1
-
-	for low < high {
-		mid := (low + high) / 2
-		if lg.positions[mid] < t {
-			low = mid + 1
-		} else {
-			high = mid
-		}
-	}
+	return lg.segmentAt(searchStop(lg.positions, t), t)
+}
 
-	if low == 0 {
-		low = 1
-	}
+func (lg basisGradient) stopPositions() []float64 {
+	return lg.positions
+}
 
+func (lg basisGradient) segmentAt(low int, t float64) Color {
+	n := len(lg.positions) - 1
 	p1 := lg.positions[low-1]
 	p2 := lg.positions[low]
 	val0 := lg.colors[low-1]
@@ -72,23 +64,12 @@ func (lg basisGradient) At(t float64) Color {
 	c := xx(val0[2], val1[2], 2)
 	d := xx(val0[3], val1[3], 3)
 
-	switch lg.mode {
-	case BlendRgb:
-		return Color{R: a, G: b, B: c, A: d}
-	case BlendLinearRgb:
-		return LinearRgb(a, b, c, d)
-	case BlendLab:
-		return Lab(a, b, c, d).Clamp()
-	case BlendOklab:
-		return Oklab(a, b, c, d).Clamp()
-	}
-
-	return Color{}
+	return colorFromSpace(lg.mode, a, b, c, d)
 }
 
-func newBasisGradient(colors []Color, positions []float64, mode BlendMode) Gradient {
+func newBasisGradient(colors []Color, positions []float64, mode BlendMode, hue HueInterpolation) Gradient {
 	gradbase := basisGradient{
-		colors:    convertColors(colors, mode),
+		colors:    convertColors(colors, mode, hue),
 		positions: positions,
 		min:       positions[0],
 		max:       positions[len(positions)-1],
@@ -0,0 +1,91 @@
+package colorgrad
+
+import (
+	"math"
+)
+
+// Cores with colors between sorted stops
+type stopCore interface {
+	stopPositions() []float64
+	// Color at t within the segment between the stops low-1 and low
+	segmentAt(low int, t float64) Color
+}
+
+// Index of the first stop at or after t, at least 1
+func searchStop(positions []float64, t float64) int {
+	low := 0
+	high := len(positions)
+
+	for low < high {
+		mid := (low + high) / 2
+		if positions[mid] < t {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+
+	if low == 0 {
+		low = 1
+	}
+	return low
+}
+
+// Get the colors at positions ts into dst, which must be at least as long as
+// ts. Same as calling At for each position, but runs of ascending positions
+// are sampled in a single pass over the stops, without allocation.
+func (g Gradient) AtMany(ts []float64, dst []Color) {
+	dst = dst[:len(ts)]
+
+	sc, ok := g.Core.(stopCore)
+	if !ok {
+		for i, t := range ts {
+			dst[i] = g.At(t)
+		}
+		return
+	}
+
+	positions := sc.stopPositions()
+	first := math.Max(positions[0], g.Min)
+	last := math.Min(positions[len(positions)-1], g.Max)
+	low := 1
+	prev := math.Inf(1)
+
+	for i, t := range ts {
+		// NaN, ends and out of range colors
+		if !(t > first && t < last) {
+			dst[i] = g.At(t)
+			continue
+		}
+		if t < prev {
+			low = searchStop(positions, t)
+		} else {
+			for positions[low] < t {
+				low++
+			}
+		}
+		prev = t
+		dst[i] = sc.segmentAt(low, t)
+	}
+}
+
+// Get the colors at positions ts into dst as 8-bit non-premultiplied RGBA,
+// the layout of image.NRGBA pixels. dst must be at least 4*len(ts) long.
+func (g Gradient) AtManyNRGBA(ts []float64, dst []byte) {
+	dst = dst[:len(ts)*4]
+
+	var buf [64]Color
+	for len(ts) > 0 {
+		n := len(ts)
+		if n > len(buf) {
+			n = len(buf)
+		}
+		g.AtMany(ts[:n], buf[:n])
+		for i, c := range buf[:n] {
+			r, gr, b, a := c.Clamp().RGBA255()
+			dst[i*4], dst[i*4+1], dst[i*4+2], dst[i*4+3] = r, gr, b, a
+		}
+		ts = ts[n:]
+		dst = dst[n*4:]
+	}
+}
@@ -0,0 +1,67 @@
+package colorgrad
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func Test_AtMany(t *testing.T) {
+	ts := []float64{math.NaN(), -1, 0, 0.05, 0.1, 0.1, 0.33, 0.5, 0.7, 0.2, 0.9, 1, 2, 0.45, 0.46}
+
+	builder := NewGradient().
+		HtmlColors("#f00", "#ff0", "#0f0", "#0ff", "#00f").
+		Domain(0, 0.2, 0.3, 0.6, 1).
+		Mode(BlendOklab).
+		NaNColor(Rgb(0.5, 0.5, 0.5, 1)).
+		UnderColor(Rgb(0, 0, 0, 1))
+
+	grads := []Gradient{}
+	for _, interp := range []Interpolation{InterpolationLinear, InterpolationSmoothstep, InterpolationCatmullRom, InterpolationBasis} {
+		grad, err := builder.Interpolation(interp).Build()
+		test(t, err, nil)
+		grads = append(grads, grad)
+	}
+	grads = append(grads, grads[0].Sharp(7, 0.3), grads[0].Reverse(), grads[2].Slice(0.2, 0.7), Rainbow())
+
+	dst := make([]Color, len(ts)+1)
+	for _, grad := range grads {
+		grad.AtMany(ts, dst)
+		for i, x := range ts {
+			test(t, fmt.Sprint(dst[i]), fmt.Sprint(grad.At(x)))
+		}
+		test(t, dst[len(ts)], Color{})
+	}
+
+	grad := grads[0]
+	allocs := testing.AllocsPerRun(10, func() {
+		grad.AtMany(ts, dst)
+	})
+	test(t, allocs, 0.0)
+
+	grad.AtMany(nil, nil)
+}
+
+func Test_AtManyNRGBA(t *testing.T) {
+	grad, err := NewGradient().
+		HtmlColors("#f00", "#00f").
+		Build()
+	test(t, err, nil)
+
+	ts := make([]float64, 150)
+	for i := range ts {
+		ts[i] = float64(i) / 149
+	}
+	dst := make([]byte, len(ts)*4)
+	grad.AtManyNRGBA(ts, dst)
+
+	for i, x := range ts {
+		r, g, b, a := grad.At(x).RGBA255()
+		testSlice(t, dst[i*4:i*4+4], []byte{r, g, b, a})
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		grad.AtManyNRGBA(ts, dst)
+	})
+	test(t, allocs, 0.0)
+}
@@ -91,3 +91,64 @@ func BenchmarkBasisGradient(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkLutGradient(b *testing.B) {
+	grad, err := NewGradient().
+		HtmlColors(colors...).
+		Mode(BlendOklab).
+		Interpolation(InterpolationCatmullRom).
+		Build()
+
+	if err != nil {
+		panic(err)
+	}
+
+	for _, interpolate := range []bool{false, true} {
+		lut := grad.Lut(1024, interpolate)
+		for _, pos := range positions {
+			b.Run(
+				fmt.Sprintf("interpolate_%v_at_%.2f", interpolate, pos), func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						lut.At(pos)
+					}
+				})
+		}
+	}
+}
+
+func BenchmarkAtMany(b *testing.B) {
+	grad, err := NewGradient().
+		HtmlColors(colors...).
+		Mode(BlendOklab).
+		Interpolation(InterpolationCatmullRom).
+		Build()
+
+	if err != nil {
+		panic(err)
+	}
+
+	ts := linspace(0, 1, 1024)
+	dst := make([]Color, len(ts))
+	pix := make([]byte, len(ts)*4)
+
+	b.Run("At", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for j, t := range ts {
+				dst[j] = grad.At(t)
+			}
+		}
+	})
+	b.Run("AtMany", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			grad.AtMany(ts, dst)
+		}
+	})
+	b.Run("AtManyNRGBA", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			grad.AtManyNRGBA(ts, pix)
+		}
+	})
+}
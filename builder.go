@@ -7,21 +7,26 @@ import (
 )
 
 type GradientBuilder struct {
-	colors             []Color
-	positions          []float64
-	mode               BlendMode
-	interpolation      Interpolation
-	invalidHtmlColors  []string
-	invalidCssGradient bool
-	clean              bool
+	colors            []Color
+	positions         []float64
+	mode              BlendMode
+	interpolation     Interpolation
+	hueInterpolation  HueInterpolation
+	invalidHtmlColors []string
+	cssError          error
+	cssGeometry       CssGeometry
+	nanColor          *Color
+	underColor        *Color
+	overColor         *Color
+	clean             bool
 }
 
 func NewGradient() *GradientBuilder {
 	return &GradientBuilder{
-		mode:               BlendRgb,
-		interpolation:      InterpolationLinear,
-		invalidCssGradient: false,
-		clean:              false,
+		mode:             BlendRgb,
+		interpolation:    InterpolationLinear,
+		hueInterpolation: HueShorter,
+		clean:            false,
 	}
 }
 
@@ -46,22 +51,38 @@ func (gb *GradientBuilder) HtmlColors(htmlColors ...string) *GradientBuilder {
 	return gb
 }
 
+// Parse color stops from a CSS gradient. Accepts either a list of color stops
+// ("red, gold 25%, blue") or a full linear-gradient(), radial-gradient() or
+// conic-gradient() function. The color interpolation method, if given as
+// "in <colorspace> [<hue-method> hue]", sets the blend mode and the hue
+// interpolation. The geometry is available from CssGeometry. If the gradient
+// is invalid, Build returns a *CssGradientError.
 func (gb *GradientBuilder) Css(s string) *GradientBuilder {
 	gb.clean = false
-	stops, ok := parseCss(s)
-	if !ok {
-		gb.invalidCssGradient = true
+	css, err := parseCssGradient(s)
+	if err != nil {
+		gb.cssError = err
 		return gb
 	}
-	gb.colors = gb.colors[:0]
-	gb.positions = gb.positions[:0]
-	for _, st := range stops {
+	gb.colors = nil
+	gb.positions = nil
+	for _, st := range css.stops {
 		gb.colors = append(gb.colors, *st.color)
 		gb.positions = append(gb.positions, *st.pos)
 	}
+	if css.hasMode {
+		gb.mode = css.mode
+		gb.hueInterpolation = css.hue
+	}
+	gb.cssGeometry = css.geometry
 	return gb
 }
 
+// Geometry of the last gradient parsed by Css
+func (gb *GradientBuilder) CssGeometry() CssGeometry {
+	return gb.cssGeometry
+}
+
 func (gb *GradientBuilder) Domain(positions ...float64) *GradientBuilder {
 	gb.positions = positions
 	gb.clean = false
@@ -78,13 +99,42 @@ func (gb *GradientBuilder) Interpolation(mode Interpolation) *GradientBuilder {
 	return gb
 }
 
+// Set the hue interpolation method for the polar blend modes
+func (gb *GradientBuilder) HueInterpolation(method HueInterpolation) *GradientBuilder {
+	gb.hueInterpolation = method
+	return gb
+}
+
+// Set the color for NaN
+func (gb *GradientBuilder) NaNColor(col Color) *GradientBuilder {
+	gb.nanColor = &col
+	return gb
+}
+
+// Set the color for positions below the domain
+func (gb *GradientBuilder) UnderColor(col Color) *GradientBuilder {
+	gb.underColor = &col
+	return gb
+}
+
+// Set the color for positions above the domain
+func (gb *GradientBuilder) OverColor(col Color) *GradientBuilder {
+	gb.overColor = &col
+	return gb
+}
+
 func (gb *GradientBuilder) Reset() *GradientBuilder {
-	gb.colors = gb.colors[:0]
-	gb.positions = gb.positions[:0]
+	gb.colors = nil
+	gb.positions = nil
 	gb.mode = BlendRgb
 	gb.interpolation = InterpolationLinear
+	gb.hueInterpolation = HueShorter
 	gb.invalidHtmlColors = gb.invalidHtmlColors[:0]
-	gb.invalidCssGradient = false
+	gb.cssError = nil
+	gb.cssGeometry = CssGeometry{}
+	gb.nanColor = nil
+	gb.underColor = nil
+	gb.overColor = nil
 	gb.clean = false
 	return gb
 }
@@ -98,8 +148,8 @@ func (gb *GradientBuilder) prepareBuild() error {
 		return fmt.Errorf("invalid HTML colors: %q", gb.invalidHtmlColors)
 	}
 
-	if gb.invalidCssGradient {
-		return fmt.Errorf("invalid CSS gradient")
+	if gb.cssError != nil {
+		return gb.cssError
 	}
 
 	var colors []Color
@@ -137,8 +187,9 @@ func (gb *GradientBuilder) prepareBuild() error {
 		return fmt.Errorf("invalid domain")
 	}
 
-	gb.colors = gb.colors[:0]
-	gb.positions = gb.positions[:0]
+	// New slices, built gradients keep the old ones
+	gb.colors = nil
+	gb.positions = nil
 
 	prev := positions[0]
 	lastIdx := len(positions) - 1
@@ -177,19 +228,30 @@ func (gb *GradientBuilder) Build() (Gradient, error) {
 		}, err
 	}
 
-	if gb.interpolation == InterpolationLinear {
-		return newLinearGradient(gb.colors, gb.positions, gb.mode), nil
-	}
+	var grad Gradient
 
-	if gb.interpolation == InterpolationSmoothstep {
-		return newSmoothstepGradient(gb.colors, gb.positions, gb.mode), nil
+	switch gb.interpolation {
+	case InterpolationLinear:
+		grad = newLinearGradient(gb.colors, gb.positions, gb.mode, gb.hueInterpolation)
+	case InterpolationSmoothstep:
+		grad = newSmoothstepGradient(gb.colors, gb.positions, gb.mode, gb.hueInterpolation)
+	case InterpolationBasis:
+		grad = newBasisGradient(gb.colors, gb.positions, gb.mode, gb.hueInterpolation)
+	default:
+		grad = newCatmullRomGradient(gb.colors, gb.positions, gb.mode, gb.hueInterpolation)
 	}
 
-	if gb.interpolation == InterpolationBasis {
-		return newBasisGradient(gb.colors, gb.positions, gb.mode), nil
+	grad.spec = &GradientSpec{
+		Colors:           append([]Color{}, gb.colors...),
+		Domain:           append([]float64{}, gb.positions...),
+		Mode:             gb.mode,
+		Interpolation:    gb.interpolation,
+		HueInterpolation: gb.hueInterpolation,
 	}
-
-	return newCatmullRomGradient(gb.colors, gb.positions, gb.mode), nil
+	grad.NaN = gb.nanColor
+	grad.Under = gb.underColor
+	grad.Over = gb.overColor
+	return grad, nil
 }
 
 // For testing purposes
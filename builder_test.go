@@ -1,7 +1,9 @@
 package colorgrad
 
 import (
+	"errors"
 	"image/color"
+	"strings"
 	"testing"
 )
 
@@ -228,23 +230,182 @@ func Test_CssGradient(t *testing.T) {
 		test(t, 1.0, dmax)*/
 	}
 
+	// Invalid format
+	invalid := []struct {
+		s      string
+		token  string
+		offset int
+	}{
+		{"", "", 0},
+		{" ", " ", 0},
+		{"reds, blue", "reds", 0},
+		{"0, red, lime", "0", 0},
+		{"red, lime, 100%", "100%", 11},
+		{"deeppink, 0.4, 0.9, pink", "0.4", 10},
+		{"50%", "50%", 0},
+		{"0%, 100%", "0%", 0},
+		{"æ", "æ", 0},
+		{"red â 15%, blue", "â", 4},
+		{"red, ä, blue", "ä", 5},
+	}
+	for _, d := range invalid {
+		_, err := NewGradient().Css(d.s).Build()
+		testTrue(t, err != nil)
+		testTrue(t, strings.HasPrefix(err.Error(), "invalid CSS gradient"))
+
+		var cssErr *CssGradientError
+		testTrue(t, errors.As(err, &cssErr))
+		test(t, cssErr.Token, d.token)
+		test(t, cssErr.Offset, d.offset)
+	}
+}
+
+func Test_CssGradientFunction(t *testing.T) {
+	testData := []struct {
+		s        string
+		position []float64
+		colors   []string
+		mode     BlendMode
+		hue      HueInterpolation
+	}{
+		{
+			"linear-gradient(red, blue)",
+			[]float64{0, 1},
+			[]string{"#ff0000", "#0000ff"},
+			BlendRgb, HueShorter,
+		},
+		{
+			"linear-gradient(in oklch, 45deg, red 10%, blue)",
+			[]float64{0, 0.1, 1},
+			[]string{"#ff0000", "#ff0000", "#0000ff"},
+			BlendOklch, HueShorter,
+		},
+		{
+			"linear-gradient(to top left in hsl longer hue, red, blue)",
+			[]float64{0, 1},
+			[]string{"#ff0000", "#0000ff"},
+			BlendHsl, HueLonger,
+		},
+		{
+			"repeating-linear-gradient(red 0px, blue 20px, red 40px)",
+			[]float64{0, 20, 40},
+			[]string{"#ff0000", "#0000ff", "#ff0000"},
+			BlendRgb, HueShorter,
+		},
+		{
+			"radial-gradient(circle 40px at top right, red, 30%, blue)",
+			[]float64{0, 0.3, 1},
+			[]string{"#ff0000", "#800080", "#0000ff"},
+			BlendRgb, HueShorter,
+		},
+		{
+			"conic-gradient(from 0.25turn at 10px 20px, red 0deg, blue 180deg, red)",
+			[]float64{0, 0.5, 1},
+			[]string{"#ff0000", "#0000ff", "#ff0000"},
+			BlendRgb, HueShorter,
+		},
+		{
+			"LINEAR-GRADIENT(\n\t#f00,\n\t#00f 50%\n)",
+			[]float64{0, 0.5, 1},
+			[]string{"#ff0000", "#0000ff", "#0000ff"},
+			BlendRgb, HueShorter,
+		},
+	}
+
+	for _, d := range testData {
+		gb := NewGradient()
+		gb.Css(d.s)
+		_, err := gb.Build()
+
+		test(t, err, nil)
+		testSliceF(t, d.position, *gb.GetPositions())
+		testSlice(t, d.colors, colors2hex(*gb.GetColors()))
+		test(t, gb.mode, d.mode)
+		test(t, gb.hueInterpolation, d.hue)
+	}
+
+	// Geometry
+	var geom CssGeometry
+
+	geom = NewGradient().Css("linear-gradient(red, blue)").CssGeometry()
+	test(t, geom.Kind, CssLinearGradient)
+	test(t, geom.Angle, 180.0)
+	test(t, geom.Unit, "%")
+
+	geom = NewGradient().Css("linear-gradient(0.5turn, red, blue)").CssGeometry()
+	test(t, geom.Angle, 180.0)
+
+	geom = NewGradient().Css("linear-gradient(to bottom left, red, blue)").CssGeometry()
+	test(t, geom.Angle, 225.0)
+	test(t, geom.To, "bottom left")
+
+	geom = NewGradient().Css("repeating-linear-gradient(red 0px, blue 1in)").CssGeometry()
+	test(t, geom.Repeating, true)
+	test(t, geom.Unit, "px")
+
+	geom = NewGradient().Css("radial-gradient(red, blue)").CssGeometry()
+	test(t, geom.Kind, CssRadialGradient)
+	test(t, geom.Shape, "ellipse")
+	test(t, geom.Extent, "farthest-corner")
+	test(t, geom.Position, [2]CssLength{{50, "%"}, {50, "%"}})
+
+	geom = NewGradient().Css("radial-gradient(40px 20% at center top, red, blue)").CssGeometry()
+	test(t, geom.Kind, CssRadialGradient)
+	test(t, geom.Shape, "ellipse")
+	test(t, geom.Extent, "")
+	test(t, len(geom.Radius), 2)
+	test(t, geom.Radius[0], CssLength{40, "px"})
+	test(t, geom.Radius[1], CssLength{20, "%"})
+	test(t, geom.Position, [2]CssLength{{50, "%"}, {0, "%"}})
+
+	geom = NewGradient().Css("conic-gradient(from 90deg at left 25%, red, blue)").CssGeometry()
+	test(t, geom.Kind, CssConicGradient)
+	test(t, geom.Angle, 90.0)
+	test(t, geom.Position, [2]CssLength{{0, "%"}, {25, "%"}})
+
 	// Invalid format
 	invalid := []string{
-		"",
-		" ",
-		"reds, blue",
-		"0, red, lime",
-		"red, lime, 100%",
-		"deeppink, 0.4, 0.9, pink",
-		"50%",
-		"0%, 100%",
-		"æ",
-		"red â 15%, blue",
-		"red, ä, blue",
+		"linear-gradient()",
+		"linear-gradient(45deg)",
+		"linear-gradient(, red, blue)",
+		"linear-gradient(45deg, to right, red, blue)",
+		"linear-gradient(to top bottom, red, blue)",
+		"linear-gradient(in xyz, red, blue)",
+		"linear-gradient(in oklab longer hue, red, blue)",
+		"linear-gradient(red 10px, blue 50%)",
+		"linear-gradient(red 10px, blue)",
+		"linear-gradient(red 10em, blue 20em)",
+		"radial-gradient(circle 10px 20px, red, blue)",
+		"radial-gradient(circle 10%, red, blue)",
+		"radial-gradient(at top top, red, blue)",
+		"conic-gradient(to right, red, blue)",
+		"conic-gradient(red 10px, blue)",
 	}
 	for _, s := range invalid {
 		_, err := NewGradient().Css(s).Build()
 		testTrue(t, err != nil)
-		test(t, err.Error(), "invalid CSS gradient")
+	}
+
+	// Error position
+	errData := []struct {
+		s      string
+		token  string
+		offset int
+		reason string
+	}{
+		{"  linear-gradient(in xyz, red, blue)", "xyz", 21, "unsupported color space"},
+		{"linear-gradient(45deg, to right, red, blue)", "to", 23, "duplicate direction"},
+		{"radial-gradient(circle at top top, red, blue)", "at", 23, "invalid position"},
+		{"linear-gradient(red 10px, blue 50%)", "50%", 31, "percentage mixed with length positions"},
+		{"conic-gradient(red, 20%, blue, 30%)", "30%", 31, "transition hint must be between two color stops"},
+	}
+	for _, d := range errData {
+		_, err := NewGradient().Css(d.s).Build()
+		var cssErr *CssGradientError
+		testTrue(t, errors.As(err, &cssErr))
+		test(t, cssErr.Token, d.token)
+		test(t, cssErr.Offset, d.offset)
+		test(t, cssErr.Reason, d.reason)
+		test(t, d.s[cssErr.Offset:cssErr.Offset+len(cssErr.Token)], d.token)
 	}
 }
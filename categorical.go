@@ -0,0 +1,77 @@
+package colorgrad
+
+// Reference: https://github.com/d3/d3-scale-chromatic#categorical
+
+// List of distinct colors for categorical data
+type Palette []Color
+
+// Get the color for category i, cycling through the palette. Negative i
+// cycles backwards.
+func (p Palette) At(i int) Color {
+	if len(p) == 0 {
+		return Color{}
+	}
+	i %= len(p)
+	if i < 0 {
+		i += len(p)
+	}
+	return p[i]
+}
+
+// Return a hard-edged gradient with one segment per color, in the domain
+// [0..1]
+func (p Palette) Gradient() Gradient {
+	colors := []Color(p)
+	if len(colors) == 0 {
+		colors = []Color{{}}
+	}
+	return newSharpGradient(colors, 0, 1, 0)
+}
+
+func palette(data []uint32) Palette {
+	colors := make(Palette, len(data))
+	for i, v := range data {
+		colors[i] = u32ToColor(v)
+	}
+	return colors
+}
+
+func Category10() Palette {
+	return palette([]uint32{0x1f77b4, 0xff7f0e, 0x2ca02c, 0xd62728, 0x9467bd, 0x8c564b, 0xe377c2, 0x7f7f7f, 0xbcbd22, 0x17becf})
+}
+
+func Accent() Palette {
+	return palette([]uint32{0x7fc97f, 0xbeaed4, 0xfdc086, 0xffff99, 0x386cb0, 0xf0027f, 0xbf5b17, 0x666666})
+}
+
+func Dark2() Palette {
+	return palette([]uint32{0x1b9e77, 0xd95f02, 0x7570b3, 0xe7298a, 0x66a61e, 0xe6ab02, 0xa6761d, 0x666666})
+}
+
+func Paired() Palette {
+	return palette([]uint32{0xa6cee3, 0x1f78b4, 0xb2df8a, 0x33a02c, 0xfb9a99, 0xe31a1c, 0xfdbf6f, 0xff7f00, 0xcab2d6, 0x6a3d9a, 0xffff99, 0xb15928})
+}
+
+func Pastel1() Palette {
+	return palette([]uint32{0xfbb4ae, 0xb3cde3, 0xccebc5, 0xdecbe4, 0xfed9a6, 0xffffcc, 0xe5d8bd, 0xfddaec, 0xf2f2f2})
+}
+
+func Pastel2() Palette {
+	return palette([]uint32{0xb3e2cd, 0xfdcdac, 0xcbd5e8, 0xf4cae4, 0xe6f5c9, 0xfff2ae, 0xf1e2cc, 0xcccccc})
+}
+
+func Set1() Palette {
+	return palette([]uint32{0xe41a1c, 0x377eb8, 0x4daf4a, 0x984ea3, 0xff7f00, 0xffff33, 0xa65628, 0xf781bf, 0x999999})
+}
+
+func Set2() Palette {
+	return palette([]uint32{0x66c2a5, 0xfc8d62, 0x8da0cb, 0xe78ac3, 0xa6d854, 0xffd92f, 0xe5c494, 0xb3b3b3})
+}
+
+func Set3() Palette {
+	return palette([]uint32{0x8dd3c7, 0xffffb3, 0xbebada, 0xfb8072, 0x80b1d3, 0xfdb462, 0xb3de69, 0xfccde5, 0xd9d9d9, 0xbc80bd, 0xccebc5, 0xffed6f})
+}
+
+func Tableau10() Palette {
+	return palette([]uint32{0x4e79a7, 0xf28e2c, 0xe15759, 0x76b7b2, 0x59a14f, 0xedc949, 0xaf7aa1, 0xff9da7, 0x9c755f, 0xbab0ab})
+}
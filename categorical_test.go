@@ -0,0 +1,58 @@
+package colorgrad
+
+import (
+	"testing"
+)
+
+func Test_Palette(t *testing.T) {
+	p := Category10()
+	test(t, len(p), 10)
+	test(t, p.At(0).HexString(), "#1f77b4")
+	test(t, p.At(9).HexString(), "#17becf")
+	test(t, p.At(10).HexString(), "#1f77b4")
+	test(t, p.At(23).HexString(), "#d62728")
+	test(t, p.At(-1).HexString(), "#17becf")
+	test(t, p.At(-11).HexString(), "#17becf")
+
+	var colors []Color = Set1()
+	test(t, colors[0].HexString(), "#e41a1c")
+
+	for _, p := range []Palette{Accent(), Dark2(), Pastel2(), Set2()} {
+		test(t, len(p), 8)
+	}
+	for _, p := range []Palette{Pastel1(), Set1()} {
+		test(t, len(p), 9)
+	}
+	for _, p := range []Palette{Paired(), Set3()} {
+		test(t, len(p), 12)
+	}
+	test(t, len(Tableau10()), 10)
+
+	grad := Tableau10().Gradient()
+	test(t, grad.Min, 0.0)
+	test(t, grad.Max, 1.0)
+	testSlice(t, colors2hex(grad.Colors(10)), colors2hex(Tableau10()))
+	test(t, grad.At(0.09).HexString(), "#4e79a7")
+	test(t, grad.At(0.11).HexString(), "#f28e2c")
+	test(t, grad.At(0.99).HexString(), "#bab0ab")
+
+	// Empty
+	test(t, Palette{}.At(3), Color{})
+	testTrue(t, isZeroGradient(Palette{}.Gradient()))
+
+	// As a preset
+	grad, err := Preset("set3")
+	test(t, err, nil)
+	testSlice(t, colors2hex(grad.Colors(12)), colors2hex(Set3()))
+	info, _ := LookupPreset("Set3")
+	test(t, info.Category, CategoryQualitative)
+	testTrue(t, !info.ColorblindSafe)
+
+	for _, p := range PresetsByCategory(CategoryQualitative) {
+		testTrue(t, !p.ColorblindSafe)
+	}
+	// Only the 3-class Dark2 is colorblind safe
+	cvd := Cvd{Deficiency: Protanopia, Severity: 1}
+	testTrue(t, Dark2().CheckCvd(cvd).MinDeltaE < 5)
+	testTrue(t, Dark2()[:3].CheckCvd(cvd).MinDeltaE > 10)
+}
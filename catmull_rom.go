@@ -56,24 +56,13 @@ type catmullRomGradient struct {
 	last      Color
 }
 
-func newCatmullRomGradient(colors []Color, positions []float64, space BlendMode) Gradient {
+func newCatmullRomGradient(colors []Color, positions []float64, space BlendMode, hue HueInterpolation) Gradient {
 	n := len(colors)
 	a := make([]float64, n)
 	b := make([]float64, n)
 	c := make([]float64, n)
 	d := make([]float64, n)
-	for i, col := range colors {
-		var arr [4]float64
-		switch space {
-		case BlendRgb:
-			arr = [4]float64{col.R, col.G, col.B, col.A}
-		case BlendLinearRgb:
-			arr = col2linearRgb(col)
-		case BlendLab:
-			arr = col2lab(col)
-		case BlendOklab:
-			arr = col2oklab(col)
-		}
+	for i, arr := range convertColors(colors, space, hue) {
 		a[i] = arr[0]
 		b[i] = arr[1]
 		c[i] = arr[2]
@@ -123,22 +112,14 @@ func (g catmullRomGradient) At(t float64) Color {
 		return g.last
 	}
 
-	low := 0
-	high := len(g.positions)
-
-	for low < high {
-		mid := (low + high) / 2
-		if g.positions[mid] < t {
-			low = mid + 1
-		} else {
-			high = mid
-		}
-	}
+	return g.segmentAt(searchStop(g.positions, t), t)
+}
 
-	if low == 0 {
-		low = 1
-	}
+func (g catmullRomGradient) stopPositions() []float64 {
+	return g.positions
+}
 
+func (g catmullRomGradient) segmentAt(low int, t float64) Color {
 	pos0 := g.positions[low-1]
 	pos1 := g.positions[low]
 	seg_a := g.segments[low-1][0]
@@ -155,16 +136,5 @@ func (g catmullRomGradient) At(t float64) Color {
 	c := seg_c[0]*t3 + seg_c[1]*t2 + seg_c[2]*t1 + seg_c[3]
 	d := seg_d[0]*t3 + seg_d[1]*t2 + seg_d[2]*t1 + seg_d[3]
 
-	switch g.mode {
-	case BlendRgb:
-		return Color{R: a, G: b, B: c, A: d}
-	case BlendLinearRgb:
-		return LinearRgb(a, b, c, d)
-	case BlendLab:
-		return Lab(a, b, c, d).Clamp()
-	case BlendOklab:
-		return Oklab(a, b, c, d).Clamp()
-	}
-
-	return Color{}
+	return colorFromSpace(g.mode, a, b, c, d)
 }
@@ -0,0 +1,107 @@
+package colorgrad
+
+// Colormap is a gradient loaded from, or saved to, the colormap formats of
+// scientific tools: GMT .cpt, ParaView XML and JSON, and matplotlib segment
+// data. The domain of the gradient is the data range of the colormap. The
+// parsers also set the background, foreground and NaN colors as the Under,
+// Over and NaN colors of the gradient, and the writers use those of the
+// gradient when the colormap colors are nil.
+type Colormap struct {
+	Name     string
+	Gradient Gradient
+	// Color for values below the domain, nil if not specified
+	Background *Color
+	// Color for values above the domain, nil if not specified
+	Foreground *Color
+	// Color for NaN, nil if not specified
+	NaN *Color
+	// Every segment has a single color
+	Discrete bool
+}
+
+// Set the extra colors of the gradient from the colormap
+func (cm *Colormap) applyColors() {
+	cm.Gradient.Under = cm.Background
+	cm.Gradient.Over = cm.Foreground
+	cm.Gradient.NaN = cm.NaN
+}
+
+// Background, foreground and NaN colors, from the gradient if not set
+func (cm Colormap) colors() (*Color, *Color, *Color) {
+	bg, fg, nan := cm.Background, cm.Foreground, cm.NaN
+	if bg == nil {
+		bg = cm.Gradient.Under
+	}
+	if fg == nil {
+		fg = cm.Gradient.Over
+	}
+	if nan == nil {
+		nan = cm.Gradient.NaN
+	}
+	return bg, fg, nan
+}
+
+// Linear RGB segments of g, positions in the gradient domain. Gradients from
+// ParseCpt, and linear or sharp gradients blended in RGB, are exact.
+func colormapSegments(g Gradient) []gimpSegment {
+	if core, ok := g.Core.(gimpGradient); ok && isLinearRgb(core.segments) {
+		return core.segments
+	}
+	segments := linearRgbSegments(g, GgrOptions{})
+	if g.Min == 0 && g.Max == 1 {
+		return segments
+	}
+	res := make([]gimpSegment, len(segments))
+	d := g.Max - g.Min
+	for i, seg := range segments {
+		seg.lpos = g.Min + seg.lpos*d
+		seg.mpos = g.Min + seg.mpos*d
+		seg.rpos = g.Min + seg.rpos*d
+		res[i] = seg
+	}
+	res[0].lpos = g.Min
+	res[len(res)-1].rpos = g.Max
+	return res
+}
+
+func isLinearRgb(segments []gimpSegment) bool {
+	for _, seg := range segments {
+		if seg.blending != linear || seg.coloring != rgb {
+			return false
+		}
+		if seg.lcolor != seg.rcolor && 2*seg.mpos != seg.lpos+seg.rpos {
+			return false
+		}
+	}
+	return true
+}
+
+// Color stops of the segments, with a pair of stops at the same position
+// where the colors of adjacent segments differ.
+func segmentStops(segments []gimpSegment) ([]Color, []float64) {
+	colors := []Color{}
+	positions := []float64{}
+	add := func(c Color, p float64) {
+		n := len(colors)
+		if n > 0 && positions[n-1] == p && colors[n-1] == c {
+			return
+		}
+		colors = append(colors, c)
+		positions = append(positions, p)
+	}
+	for _, seg := range segments {
+		add(seg.lcolor.Clamp(), seg.lpos)
+		add(seg.rcolor.Clamp(), seg.rpos)
+	}
+	return colors, positions
+}
+
+// Segments between each pair of stops, the stops may have duplicate
+// positions for hard edges.
+func stopSegments(colors []Color, positions []float64) []gimpSegment {
+	arr := make([][4]float64, len(colors))
+	for i, c := range colors {
+		arr[i] = [4]float64{c.R, c.G, c.B, c.A}
+	}
+	return linearSegments(arr, positions)
+}
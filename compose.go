@@ -0,0 +1,103 @@
+package colorgrad
+
+import (
+	"math"
+)
+
+// Maps the domain [min..max] linearly to positions [from..to] of a core
+type remapGradient struct {
+	core GradientCore
+	min  float64
+	max  float64
+	from float64
+	to   float64
+}
+
+func (rg remapGradient) At(t float64) Color {
+	if math.IsNaN(t) {
+		return rg.core.At(t)
+	}
+	t = math.Max(rg.min, math.Min(rg.max, t))
+	return rg.core.At(rg.from + norm(t, rg.min, rg.max)*(rg.to-rg.from))
+}
+
+// Gradient with domain [dmin..dmax] showing positions [from..to] of g
+func remap(g Gradient, dmin, dmax, from, to float64) Gradient {
+	core := g.Core
+	// Don't stack remaps
+	if rg, ok := core.(remapGradient); ok {
+		core = rg.core
+		from = rg.from + norm(from, rg.min, rg.max)*(rg.to-rg.from)
+		to = rg.from + norm(to, rg.min, rg.max)*(rg.to-rg.from)
+	}
+	return Gradient{
+		Core: remapGradient{
+			core: core,
+			min:  dmin,
+			max:  dmax,
+			from: from,
+			to:   to,
+		},
+		Min:   dmin,
+		Max:   dmax,
+		NaN:   g.NaN,
+		Under: g.Under,
+		Over:  g.Over,
+	}
+}
+
+// Return the gradient with the colors in reverse order. The under and over
+// colors are swapped.
+func (g Gradient) Reverse() Gradient {
+	grad := remap(g, g.Min, g.Max, g.Max, g.Min)
+	grad.Under, grad.Over = g.Over, g.Under
+	return grad
+}
+
+// Return the part [t0..t1] of the gradient, stretched over the same domain.
+// If t0 > t1 the part is reversed.
+func (g Gradient) Slice(t0, t1 float64) Gradient {
+	return remap(g, g.Min, g.Max, t0, t1)
+}
+
+// Return the gradient with the domain [min..max]
+func (g Gradient) WithDomain(min, max float64) Gradient {
+	return remap(g, min, max, g.Min, g.Max)
+}
+
+type concatGradient struct {
+	a  GradientCore
+	b  GradientCore
+	at float64
+}
+
+func (cg concatGradient) At(t float64) Color {
+	if t <= cg.at || math.IsNaN(t) {
+		return cg.a.At(t)
+	}
+	return cg.b.At(t)
+}
+
+// Join two gradients: a over [0..at] and b over [at..1]. The NaN and under
+// colors are those of a, the over color is that of b.
+func Concat(a, b Gradient, at float64) Gradient {
+	at = clamp01(at)
+	if at == 0 {
+		return b.WithDomain(0, 1)
+	}
+	if at == 1 {
+		return a.WithDomain(0, 1)
+	}
+	return Gradient{
+		Core: concatGradient{
+			a:  a.WithDomain(0, at).Core,
+			b:  b.WithDomain(at, 1).Core,
+			at: at,
+		},
+		Min:   0,
+		Max:   1,
+		NaN:   a.NaN,
+		Under: a.Under,
+		Over:  b.Over,
+	}
+}
@@ -0,0 +1,81 @@
+package colorgrad
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_Reverse(t *testing.T) {
+	grad, _ := NewGradient().
+		HtmlColors("red", "lime", "blue").
+		Domain(-1, 1).
+		UnderColor(Rgb(0, 0, 0, 1)).
+		Build()
+
+	rev := grad.Reverse()
+	test(t, rev.Min, -1.0)
+	test(t, rev.Max, 1.0)
+	testSlice(t, colors2hex(rev.Colors(3)), []string{"#0000ff", "#00ff00", "#ff0000"})
+	test(t, rev.At(0.5).HexString(), grad.At(-0.5).HexString())
+	test(t, rev.At(-2).HexString(), "#0000ff")
+	test(t, rev.At(2).HexString(), "#000000")
+	testSlice(t, colors2hex(rev.Reverse().Colors(5)), colors2hex(grad.Colors(5)))
+	testSlice(t, colors2hex(rev.Sharp(3, 0).Colors(3)), []string{"#0000ff", "#00ff00", "#ff0000"})
+	test(t, rev.RepeatAt(1.5).HexString(), rev.At(-0.5).HexString())
+	test(t, rev.ReflectAt(1.5).HexString(), rev.At(0.5).HexString())
+}
+
+func Test_Slice(t *testing.T) {
+	grad, _ := NewGradient().
+		HtmlColors("#000", "#fff").
+		Build()
+
+	sl := grad.Slice(0.5, 1)
+	test(t, sl.Min, 0.0)
+	test(t, sl.Max, 1.0)
+	testSlice(t, colors2hex(sl.Colors(3)), []string{"#808080", "#bfbfbf", "#ffffff"})
+	// Clamped to the slice
+	test(t, sl.At(-1).HexString(), "#808080")
+	test(t, sl.At(math.NaN()).HexString(), "#000000")
+
+	// Slice of a slice
+	testSlice(t, colors2hex(sl.Slice(0, 0.5).Colors(2)), []string{"#808080", "#bfbfbf"})
+
+	// Reversed part
+	testSlice(t, colors2hex(grad.Slice(0.5, 0).Colors(2)), []string{"#808080", "#000000"})
+}
+
+func Test_WithDomain(t *testing.T) {
+	grad := Rainbow()
+	dg := grad.WithDomain(100, 200)
+	test(t, dg.Min, 100.0)
+	test(t, dg.Max, 200.0)
+	testSlice(t, colors2hex(dg.Colors(9)), colors2hex(grad.Colors(9)))
+	test(t, dg.At(150).HexString(), grad.At(0.5).HexString())
+	test(t, dg.At(50).HexString(), grad.At(0).HexString())
+	test(t, dg.At(250).HexString(), grad.At(1).HexString())
+	test(t, dg.RepeatAt(275).HexString(), grad.At(0.75).HexString())
+	test(t, dg.ReflectAt(275).HexString(), grad.At(0.25).HexString())
+	testSlice(t, colors2hex(dg.WithDomain(-1, 1).Colors(5)), colors2hex(grad.Colors(5)))
+}
+
+func Test_Concat(t *testing.T) {
+	a, _ := NewGradient().HtmlColors("blue", "white").Domain(0, 10).Build()
+	b, _ := NewGradient().HtmlColors("white", "red").Build()
+	b.Over = &Color{R: 0, G: 0, B: 0, A: 1}
+
+	grad := Concat(a, b, 0.25)
+	test(t, grad.Min, 0.0)
+	test(t, grad.Max, 1.0)
+	test(t, grad.At(0).HexString(), "#0000ff")
+	test(t, grad.At(0.125).HexString(), "#8080ff")
+	test(t, grad.At(0.25).HexString(), "#ffffff")
+	test(t, grad.At(0.625).HexString(), "#ff8080")
+	test(t, grad.At(1).HexString(), "#ff0000")
+	test(t, grad.At(-1).HexString(), "#0000ff")
+	test(t, grad.At(2).HexString(), "#000000")
+	test(t, grad.WithDomain(-1, 1).At(-0.5).HexString(), "#ffffff")
+
+	testSlice(t, colors2hex(Concat(a, b, 0).Colors(2)), []string{"#ffffff", "#ff0000"})
+	testSlice(t, colors2hex(Concat(a, b, 1).Colors(2)), []string{"#0000ff", "#ffffff"})
+}
@@ -0,0 +1,313 @@
+package colorgrad
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mazznoer/csscolorparser"
+)
+
+// Reference: https://docs.generic-mapping-tools.org/latest/reference/cpts.html
+
+// CptSyntaxError is returned by ParseCpt when the color palette table is
+// invalid.
+type CptSyntaxError struct {
+	// Line number, starting at 1
+	Line int
+	// The offending token, may be the whole line
+	Token  string
+	Reason string
+}
+
+func (e *CptSyntaxError) Error() string {
+	return fmt.Sprintf("invalid CPT: line %d: %s: %q", e.Line, e.Reason, e.Token)
+}
+
+type cptModel int
+
+const (
+	cptRgb cptModel = iota
+	cptHsv
+	cptCmyk
+)
+
+// ParseCpt reads a GMT color palette table. The gradient domain is the range
+// of the z-slices, and slices in the HSV color model are interpolated in HSV.
+// The B, F and N lines set the background, foreground and NaN colors.
+// Categorical tables are not supported.
+func ParseCpt(r io.Reader) (Colormap, error) {
+	cm := Colormap{
+		Gradient: Gradient{
+			Core: zeroGradient{},
+			Min:  0,
+			Max:  1,
+		},
+	}
+
+	model := cptRgb
+	segments := []gimpSegment{}
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "#") {
+			k, v, ok := strings.Cut(strings.TrimSpace(line[1:]), "=")
+			if ok && strings.TrimSpace(k) == "COLOR_MODEL" {
+				switch strings.ToUpper(strings.TrimPrefix(strings.TrimSpace(v), "+")) {
+				case "RGB":
+					model = cptRgb
+				case "HSV":
+					model = cptHsv
+				case "CMYK":
+					model = cptCmyk
+				default:
+					return cm, &CptSyntaxError{lineNo, line, "unsupported color model"}
+				}
+			}
+			continue
+		}
+
+		// Remove the label
+		if i := strings.IndexByte(line, ';'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "B", "F", "N":
+			col, _, err := parseCptColor(fields[1:], model)
+			if err != nil {
+				err.Line = lineNo
+				return cm, err
+			}
+			switch fields[0] {
+			case "B":
+				cm.Background = &col
+			case "F":
+				cm.Foreground = &col
+			case "N":
+				cm.NaN = &col
+			}
+			continue
+		}
+
+		// Annotation flag
+		switch fields[len(fields)-1] {
+		case "L", "U", "B":
+			fields = fields[:len(fields)-1]
+		}
+
+		n := len(fields)/2 - 1
+		if len(fields)%2 != 0 || n < 1 {
+			return cm, &CptSyntaxError{lineNo, line, "invalid slice"}
+		}
+
+		z0, ok0 := parseFloat(fields[0])
+		z1, ok1 := parseFloat(fields[n+1])
+		if !ok0 || !ok1 {
+			return cm, &CptSyntaxError{lineNo, line, "invalid z-value"}
+		}
+		if z1 < z0 {
+			return cm, &CptSyntaxError{lineNo, fields[n+1], "decreasing z-value"}
+		}
+
+		lcolor, lhue, err := parseCptColor(fields[1:n+1], model)
+		if err == nil {
+			var rcolor Color
+			var rhue float64
+			rcolor, rhue, err = parseCptColor(fields[n+2:], model)
+			if err == nil {
+				coloring := rgb
+				if model == cptHsv && lcolor != rcolor {
+					switch {
+					case lhue == rhue:
+						coloring = hsvSameHue
+					case lhue < rhue:
+						coloring = hsvCcw
+					default:
+						coloring = hsvCw
+					}
+				}
+				segments = append(segments, gimpSegment{
+					lcolor:   lcolor,
+					rcolor:   rcolor,
+					lpos:     z0,
+					mpos:     (z0 + z1) / 2,
+					rpos:     z1,
+					blending: linear,
+					coloring: coloring,
+				})
+			}
+		}
+		if err != nil {
+			err.Line = lineNo
+			return cm, err
+		}
+
+		if i := len(segments) - 1; i > 0 {
+			prev := segments[i-1].rpos
+			if math.Abs(z0-prev) > 1e-6*math.Max(1, math.Abs(prev)) {
+				return cm, &CptSyntaxError{lineNo, fields[0], "slices are not contiguous"}
+			}
+			segments[i].lpos = prev
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return cm, err
+	}
+
+	if len(segments) == 0 {
+		return cm, &CptSyntaxError{lineNo + 1, "", "no z-slices"}
+	}
+
+	dmin := segments[0].lpos
+	dmax := segments[len(segments)-1].rpos
+	if dmax-dmin < epsilon {
+		return cm, &CptSyntaxError{lineNo + 1, "", "empty domain"}
+	}
+
+	cm.Discrete = true
+	for _, seg := range segments {
+		if seg.lcolor != seg.rcolor {
+			cm.Discrete = false
+			break
+		}
+	}
+
+	cm.Gradient = Gradient{
+		Core: gimpGradient{
+			segments: segments,
+			min:      dmin,
+			max:      dmax,
+		},
+		Min: dmin,
+		Max: dmax,
+	}
+	cm.applyColors()
+	return cm, nil
+}
+
+// Parse a color given as one field (r/g/b, h-s-v, c/m/y/k, gray, name or hex,
+// with optional @transparency) or as separate components in the color model.
+// Also returns the hue, which sets the direction of HSV interpolation.
+func parseCptColor(fields []string, model cptModel) (Color, float64, *CptSyntaxError) {
+	if len(fields) == 0 {
+		return Color{}, 0, &CptSyntaxError{0, "", "missing color"}
+	}
+	s := strings.Join(fields, " ")
+
+	alpha := 1.0
+	last := fields[len(fields)-1]
+	if i := strings.LastIndexByte(last, '@'); i >= 0 {
+		t, ok := parseFloat(last[i+1:])
+		if !ok {
+			return Color{}, 0, &CptSyntaxError{0, s, "invalid transparency"}
+		}
+		alpha = 1 - clamp01(t/100)
+		fields = append(fields[:len(fields)-1:len(fields)-1], last[:i])
+	}
+
+	if len(fields) == 1 {
+		if fields[0] == "-" {
+			return Color{}, 0, nil
+		}
+		switch {
+		case strings.Contains(fields[0], "/"):
+			fields = strings.Split(fields[0], "/")
+			model = cptRgb
+		case strings.Count(fields[0], "-") == 2:
+			fields = strings.Split(fields[0], "-")
+			if len(fields) == 3 && fields[0] != "" {
+				model = cptHsv
+			}
+		}
+	}
+
+	v := make([]float64, len(fields))
+	for i, f := range fields {
+		x, ok := parseFloat(f)
+		if !ok {
+			if len(fields) == 1 {
+				col, err := csscolorparser.Parse(f)
+				if err != nil {
+					return Color{}, 0, &CptSyntaxError{0, s, "invalid color"}
+				}
+				col.A *= alpha
+				return col, col2hsv(col)[0], nil
+			}
+			return Color{}, 0, &CptSyntaxError{0, s, "invalid color"}
+		}
+		v[i] = x
+	}
+
+	var col Color
+	switch {
+	case len(v) == 1:
+		col = Rgb(v[0]/255, v[0]/255, v[0]/255, 1)
+	case len(v) == 3 && model == cptHsv:
+		col = Hsv(v[0], v[1], v[2], alpha)
+		return col, v[0], nil
+	case len(v) == 3:
+		col = Rgb(v[0]/255, v[1]/255, v[2]/255, 1)
+	case len(v) == 4:
+		col = cmykToColor(v[0]/100, v[1]/100, v[2]/100, v[3]/100)
+	default:
+		return Color{}, 0, &CptSyntaxError{0, s, "invalid color"}
+	}
+	col.A = alpha
+	return col, col2hsv(col)[0], nil
+}
+
+// WriteCpt writes cm as a GMT color palette table in the RGB color model.
+// Gradients from ParseCpt, and linear or sharp gradients blended in RGB, are
+// written exactly, any other gradient is approximated using linear segments.
+// The z-values are in the gradient domain.
+func WriteCpt(w io.Writer, cm Colormap) error {
+	segments := colormapSegments(cm.Gradient)
+	bg, fg, nan := cm.colors()
+
+	bw := bufio.NewWriter(w)
+	if name := strings.Join(strings.Fields(cm.Name), " "); name != "" {
+		fmt.Fprintf(bw, "# %s\n", name)
+	}
+	bw.WriteString("# COLOR_MODEL = RGB\n")
+
+	for _, seg := range segments {
+		fmt.Fprintf(bw, "%s %s %s %s\n",
+			strconv.FormatFloat(seg.lpos, 'g', -1, 64), formatCptColor(seg.lcolor),
+			strconv.FormatFloat(seg.rpos, 'g', -1, 64), formatCptColor(seg.rcolor),
+		)
+	}
+
+	for _, x := range []struct {
+		key string
+		col *Color
+	}{{"B", bg}, {"F", fg}, {"N", nan}} {
+		if x.col != nil {
+			fmt.Fprintf(bw, "%s %s\n", x.key, formatCptColor(*x.col))
+		}
+	}
+
+	return bw.Flush()
+}
+
+func formatCptColor(col Color) string {
+	col = col.Clamp()
+	r, g, b, _ := col.RGBA255()
+	s := fmt.Sprintf("%d/%d/%d", r, g, b)
+	if col.A < 1 {
+		s += "@" + formatFloat((1-col.A)*100, 1)
+	}
+	return s
+}
@@ -0,0 +1,120 @@
+package colorgrad
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func Test_ParseCpt(t *testing.T) {
+	cpt := `# Test palette
+# COLOR_MODEL = RGB
+-10 255/0/0 0 0/0/255 ; label
+0 blue 5 #00ff00 L
+5 255 255 0 10 255 255 0@50
+B black
+F white
+N 128
+`
+	cm, err := ParseCpt(strings.NewReader(cpt))
+	test(t, err, nil)
+	test(t, cm.Gradient.Min, -10.0)
+	test(t, cm.Gradient.Max, 10.0)
+	test(t, cm.Discrete, false)
+	test(t, cm.Gradient.At(-10).HexString(), "#ff0000")
+	test(t, cm.Gradient.At(-5).HexString(), "#800080")
+	test(t, cm.Gradient.At(0).HexString(), "#0000ff")
+	test(t, cm.Gradient.At(5.01).HexString(), "#ffff00")
+	test(t, cm.Gradient.At(4.999).HexString(), "#00ff00")
+	test(t, cm.Gradient.At(10).HexString(), "#ffff0080")
+	test(t, cm.Background.HexString(), "#000000")
+	test(t, cm.Foreground.HexString(), "#ffffff")
+	test(t, cm.NaN.HexString(), "#808080")
+	test(t, cm.Gradient.At(-11).HexString(), "#000000")
+	test(t, cm.Gradient.At(11).HexString(), "#ffffff")
+	test(t, cm.Gradient.At(math.NaN()).HexString(), "#808080")
+
+	// Discrete, HSV color model
+	cpt = "# COLOR_MODEL = HSV\n0 0 1 1 1 0 1 1\n1 240 1 1 2 240 1 1\n"
+	cm, err = ParseCpt(strings.NewReader(cpt))
+	test(t, err, nil)
+	test(t, cm.Discrete, true)
+	test(t, cm.Background, (*Color)(nil))
+	testSlice(t, colors2hex(cm.Gradient.Colors(3)), []string{"#ff0000", "#0000ff", "#0000ff"})
+
+	// HSV interpolation, in the direction of the hue values
+	cpt = "# COLOR_MODEL = +HSV\n0 0-1-1 1 240-1-1\n"
+	cm, err = ParseCpt(strings.NewReader(cpt))
+	test(t, err, nil)
+	test(t, cm.Gradient.At(0.5).HexString(), "#00ff00")
+
+	// Same hue, only the saturation and value change
+	cpt = "# COLOR_MODEL = HSV\n0 0-1-1 1 0-0.5-1\n1 120-0-0.5 2 120-1-1\n"
+	cm, err = ParseCpt(strings.NewReader(cpt))
+	test(t, err, nil)
+	testSlice(t, colors2hex(cm.Gradient.Colors(5)), []string{"#ff0000", "#ff4040", "#808080", "#60bf60", "#00ff00"})
+
+	// Invalid
+	data := []string{
+		"",
+		"# comment",
+		"0 red 1",
+		"0 red 1 xyz",
+		"1 red 0 blue",
+		"0 red 1 blue\n2 red 3 blue",
+		"# COLOR_MODEL = XYZ\n0 red 1 blue",
+		"0 red@x 1 blue",
+	}
+	for _, s := range data {
+		_, err := ParseCpt(strings.NewReader(s))
+		testTrue(t, err != nil)
+	}
+
+	_, err = ParseCpt(strings.NewReader("0 red 1 blue\n1 red 2 bluish"))
+	var cptErr *CptSyntaxError
+	testTrue(t, errors.As(err, &cptErr))
+	test(t, cptErr.Line, 2)
+	test(t, cptErr.Token, "bluish")
+}
+
+func Test_WriteCpt(t *testing.T) {
+	grad, _ := NewGradient().
+		HtmlColors("red", "lime", "blue").
+		Domain(-1, 0, 3).
+		Build()
+	bg := Rgb(0, 0, 0, 1)
+	nan := Rgb(1, 1, 1, 0.5)
+
+	var buf bytes.Buffer
+	err := WriteCpt(&buf, Colormap{Name: "rgb", Gradient: grad, Background: &bg, NaN: &nan})
+	test(t, err, nil)
+	test(t, buf.String(), `# rgb
+# COLOR_MODEL = RGB
+-1 255/0/0 0 0/255/0
+0 0/255/0 3 0/0/255
+B 0/0/0
+N 255/255/255@50
+`)
+
+	cm, err := ParseCpt(&buf)
+	test(t, err, nil)
+	test(t, cm.Gradient.Min, -1.0)
+	test(t, cm.Gradient.Max, 3.0)
+	test(t, cm.Foreground, (*Color)(nil))
+	test(t, cm.NaN.HexString(), "#ffffff80")
+	for _, x := range []float64{-1, -0.5, 0, 1, 2.5, 3} {
+		test(t, cm.Gradient.At(x).HexString(), grad.At(x).HexString())
+	}
+
+	// Approximated
+	buf.Reset()
+	err = WriteCpt(&buf, Colormap{Gradient: Viridis()})
+	test(t, err, nil)
+	cm, err = ParseCpt(&buf)
+	test(t, err, nil)
+	for _, x := range []float64{0, 0.1, 0.33, 0.5, 0.9, 1} {
+		testTrue(t, colorDistance(cm.Gradient.At(x), Viridis().At(x)) < 2.0/255)
+	}
+}
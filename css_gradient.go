@@ -1,55 +1,572 @@
 package colorgrad
 
 import (
+	"fmt"
 	"math"
 	"strings"
 
 	"github.com/mazznoer/csscolorparser"
 )
 
-func parseCss(s string) ([]cssGradientStop, bool) {
+type CssGradientKind int
+
+const (
+	// Plain comma separated list of color stops
+	CssStopList CssGradientKind = iota
+	CssLinearGradient
+	CssRadialGradient
+	CssConicGradient
+)
+
+func (k CssGradientKind) String() string {
+	switch k {
+	case CssStopList:
+		return "CssStopList"
+	case CssLinearGradient:
+		return "CssLinearGradient"
+	case CssRadialGradient:
+		return "CssRadialGradient"
+	case CssConicGradient:
+		return "CssConicGradient"
+	}
+	return ""
+}
+
+// CSS length or percentage. Absolute lengths are converted to px.
+type CssLength struct {
+	Value float64
+	// "%" or "px"
+	Unit string
+}
+
+// Geometry of a CSS gradient function, everything in it except the color
+// stops and the color interpolation method.
+type CssGeometry struct {
+	Kind      CssGradientKind
+	Repeating bool
+	// Direction of a linear gradient, or the start angle of a conic gradient,
+	// in degrees clockwise from "to top".
+	Angle float64
+	// Side or corner of a linear gradient given as "to <side-or-corner>",
+	// e.g. "right" or "top right". The angle of a corner depends on the size
+	// of the box; Angle assumes a square.
+	To string
+	// Ending shape of a radial gradient, "circle" or "ellipse"
+	Shape string
+	// Size of a radial gradient as an extent keyword, e.g. "farthest-corner",
+	// empty if Radius is set.
+	Extent string
+	// Explicit radius of a radial gradient, one value for a circle, two for an
+	// ellipse.
+	Radius []CssLength
+	// Center of a radial or conic gradient
+	Position [2]CssLength
+	// Unit of the color stop positions, and so of the gradient domain: "%"
+	// (the domain is 0..1) or "px".
+	Unit string
+}
+
+// CssGradientError is returned by GradientBuilder.Build when the CSS gradient
+// given to GradientBuilder.Css is invalid.
+type CssGradientError struct {
+	// The offending token, may be empty if the error is not caused by a
+	// single token (e.g. an empty gradient).
+	Token string
+	// Byte offset of Token in the input
+	Offset int
+	Reason string
+}
+
+func (e *CssGradientError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("invalid CSS gradient: %s", e.Reason)
+	}
+	return fmt.Sprintf("invalid CSS gradient: %s: %q at offset %d", e.Reason, e.Token, e.Offset)
+}
+
+func cssError(tok cssToken, reason string) *CssGradientError {
+	return &CssGradientError{Token: tok.s, Offset: tok.pos, Reason: reason}
+}
+
+// Part of the input, with its byte offset
+type cssToken struct {
+	s   string
+	pos int
+}
+
+type cssGradient struct {
+	stops    []cssGradientStop
+	geometry CssGeometry
+	// Color interpolation method, if given using "in <colorspace>"
+	hasMode bool
+	mode    BlendMode
+	hue     HueInterpolation
+}
+
+var cssGradientFunctions = map[string]CssGradientKind{
+	"linear-gradient": CssLinearGradient,
+	"radial-gradient": CssRadialGradient,
+	"conic-gradient":  CssConicGradient,
+}
+
+// Parse either a bare list of color stops or a CSS linear-gradient(),
+// radial-gradient() or conic-gradient() function, including the repeating
+// variants.
+func parseCssGradient(s string) (cssGradient, error) {
+	res := cssGradient{
+		mode: BlendRgb,
+		hue:  HueShorter,
+	}
+	res.geometry = defaultCssGeometry(CssStopList)
+	input := cssToken{s, 0}
+	trimmed := strings.TrimSpace(s)
+
+	if i := strings.IndexByte(trimmed, '('); i > 0 && strings.HasSuffix(trimmed, ")") {
+		offset := strings.Index(s, trimmed)
+		name := strings.ToLower(strings.TrimSpace(trimmed[:i]))
+		repeating := strings.HasPrefix(name, "repeating-")
+		kind, ok := cssGradientFunctions[strings.TrimPrefix(name, "repeating-")]
+		if ok {
+			res.geometry = defaultCssGeometry(kind)
+			res.geometry.Repeating = repeating
+			input = cssToken{trimmed[i+1 : len(trimmed)-1], offset + i + 1}
+		}
+	}
+
+	args := splitByComma(input)
+
+	if res.geometry.Kind != CssStopList {
+		// The prelude can be given as one argument, or split by comma as in
+		// "linear-gradient(in oklch, 45deg, red, blue)".
+		prelude := []cssToken{}
+		for len(args) > 1 && !isCssStop(args[0], res.geometry.Kind) {
+			tokens := splitBySpace(args[0])
+			if len(tokens) == 0 {
+				return res, cssError(args[0], "empty argument")
+			}
+			prelude = append(prelude, tokens...)
+			args = args[1:]
+		}
+		if err := parseCssPrelude(&res, prelude); err != nil {
+			return res, err
+		}
+	}
+
+	stops, err := parseCssStops(args, res.geometry.Kind, res.mode, res.hue)
+	res.stops = stops
+	if err == nil && stops[0].length {
+		res.geometry.Unit = "px"
+	}
+	return res, err
+}
+
+func defaultCssGeometry(kind CssGradientKind) CssGeometry {
+	g := CssGeometry{Kind: kind, Unit: "%"}
+	switch kind {
+	case CssLinearGradient:
+		g.Angle = 180
+	case CssRadialGradient:
+		g.Shape = "ellipse"
+		g.Extent = "farthest-corner"
+		g.Position = [2]CssLength{{50, "%"}, {50, "%"}}
+	case CssConicGradient:
+		g.Position = [2]CssLength{{50, "%"}, {50, "%"}}
+	}
+	return g
+}
+
+func isCssStop(arg cssToken, kind CssGradientKind) bool {
+	arr := splitBySpace(arg)
+	if len(arr) == 0 {
+		return false
+	}
+	if _, err := csscolorparser.Parse(arr[0].s); err == nil {
+		return true
+	}
+	_, _, ok := parseStopPos(arr[0].s, kind)
+	return len(arr) == 1 && ok
+}
+
+var cssColorSpaces = map[string]BlendMode{
+	"srgb":        BlendRgb,
+	"srgb-linear": BlendLinearRgb,
+	"lab":         BlendLab,
+	"oklab":       BlendOklab,
+	"oklch":       BlendOklch,
+	"lch":         BlendLch,
+	"hsl":         BlendHsl,
+	"hwb":         BlendHwb,
+}
+
+var cssHueMethods = map[string]HueInterpolation{
+	"shorter":    HueShorter,
+	"longer":     HueLonger,
+	"increasing": HueIncreasing,
+	"decreasing": HueDecreasing,
+}
+
+var cssExtents = map[string]bool{
+	"closest-side":    true,
+	"closest-corner":  true,
+	"farthest-side":   true,
+	"farthest-corner": true,
+}
+
+func parseCssPrelude(res *cssGradient, tokens []cssToken) error {
+	geom := &res.geometry
+	hasDirection := false
+	hasShape := false
+	hasSize := false
+
+	for i := 0; i < len(tokens); i++ {
+		tok := strings.ToLower(tokens[i].s)
+
+		switch {
+		case tok == "in":
+			if res.hasMode {
+				return cssError(tokens[i], "duplicate color interpolation method")
+			}
+			if i+1 >= len(tokens) {
+				return cssError(tokens[i], "missing color space")
+			}
+			i++
+			mode, ok := cssColorSpaces[strings.ToLower(tokens[i].s)]
+			if !ok {
+				return cssError(tokens[i], "unsupported color space")
+			}
+			res.hasMode = true
+			res.mode = mode
+			if i+2 < len(tokens) && strings.ToLower(tokens[i+2].s) == "hue" {
+				hue, ok := cssHueMethods[strings.ToLower(tokens[i+1].s)]
+				if !ok {
+					return cssError(tokens[i+1], "invalid hue interpolation method")
+				}
+				if hueIndex(mode) < 0 {
+					return cssError(tokens[i+1], "hue interpolation method on a non-polar color space")
+				}
+				res.hue = hue
+				i += 2
+			}
+		case tok == "to" && geom.Kind == CssLinearGradient:
+			if hasDirection {
+				return cssError(tokens[i], "duplicate direction")
+			}
+			n := 0
+			for i+1 < len(tokens) && n < 2 {
+				if _, ok := sideAngles[strings.ToLower(tokens[i+1].s)]; !ok {
+					break
+				}
+				i++
+				n++
+			}
+			if n == 0 {
+				return cssError(tokens[i], "missing side or corner")
+			}
+			sides := []string{}
+			for _, t := range tokens[i-n+1 : i+1] {
+				sides = append(sides, strings.ToLower(t.s))
+			}
+			angle, ok := sideOrCornerAngle(sides)
+			if !ok {
+				return cssError(tokens[i], "invalid side or corner")
+			}
+			geom.Angle = angle
+			geom.To = strings.Join(sides, " ")
+			hasDirection = true
+		case tok == "from" && geom.Kind == CssConicGradient:
+			if hasDirection {
+				return cssError(tokens[i], "duplicate start angle")
+			}
+			if i+1 >= len(tokens) {
+				return cssError(tokens[i], "missing angle")
+			}
+			i++
+			angle, ok := parseAngle(tokens[i].s)
+			if !ok {
+				return cssError(tokens[i], "invalid angle")
+			}
+			geom.Angle = angle
+			hasDirection = true
+		case tok == "at" && (geom.Kind == CssRadialGradient || geom.Kind == CssConicGradient):
+			pos, n, ok := parseCssPosition(tokens[i+1:])
+			if !ok {
+				return cssError(tokens[i], "invalid position")
+			}
+			geom.Position = pos
+			i += n
+		case (tok == "circle" || tok == "ellipse") && geom.Kind == CssRadialGradient:
+			if hasShape {
+				return cssError(tokens[i], "duplicate shape")
+			}
+			geom.Shape = tok
+			hasShape = true
+		case cssExtents[tok] && geom.Kind == CssRadialGradient:
+			if hasSize {
+				return cssError(tokens[i], "duplicate size")
+			}
+			geom.Extent = tok
+			hasSize = true
+		default:
+			if geom.Kind == CssLinearGradient && !hasDirection {
+				angle, ok := parseAngle(tok)
+				if !ok {
+					return cssError(tokens[i], "invalid angle")
+				}
+				geom.Angle = angle
+				hasDirection = true
+				continue
+			}
+			if geom.Kind == CssRadialGradient && !hasSize {
+				first := tokens[i]
+				for i < len(tokens) && len(geom.Radius) < 2 {
+					l, ok := parseCssLength(tokens[i].s)
+					if !ok {
+						break
+					}
+					if l.Value < 0 {
+						return cssError(tokens[i], "negative radius")
+					}
+					geom.Radius = append(geom.Radius, l)
+					i++
+				}
+				if len(geom.Radius) == 0 {
+					return cssError(first, "unexpected token")
+				}
+				i--
+				geom.Extent = ""
+				hasSize = true
+				continue
+			}
+			return cssError(tokens[i], "unexpected token")
+		}
+	}
+
+	if geom.Kind == CssRadialGradient && len(geom.Radius) > 0 {
+		if !hasShape && len(geom.Radius) == 1 {
+			geom.Shape = "circle"
+		}
+		var tok cssToken
+		for _, t := range tokens {
+			if _, ok := parseCssLength(t.s); ok {
+				tok = t
+				break
+			}
+		}
+		if geom.Shape == "circle" && len(geom.Radius) == 2 {
+			return cssError(tok, "circle takes a single radius")
+		}
+		if geom.Shape == "ellipse" && len(geom.Radius) == 1 {
+			return cssError(tok, "ellipse takes two radii")
+		}
+		if geom.Shape == "circle" && geom.Radius[0].Unit == "%" {
+			return cssError(tok, "circle radius can't be a percentage")
+		}
+	}
+	return nil
+}
+
+var sideAngles = map[string]float64{
+	"top":    0,
+	"right":  90,
+	"bottom": 180,
+	"left":   270,
+}
+
+func sideOrCornerAngle(sides []string) (float64, bool) {
+	a := sideAngles[strings.ToLower(sides[0])]
+	if len(sides) == 1 {
+		return a, true
+	}
+	b := sideAngles[strings.ToLower(sides[1])]
+	// One side must be horizontal and the other vertical
+	if math.Mod(a, 180) == math.Mod(b, 180) {
+		return 0, false
+	}
+	if math.Abs(a-b) > 180 {
+		return normalizeAngle((a + b + 360) / 2), true
+	}
+	return (a + b) / 2, true
+}
+
+var cssPositionKeywords = map[string][2]float64{
+	// offset, axis: 0 = horizontal, 1 = vertical, -1 = either
+	"left":   {0, 0},
+	"right":  {100, 0},
+	"top":    {0, 1},
+	"bottom": {100, 1},
+	"center": {50, -1},
+}
+
+// Parse a one or two value CSS <position>, returns the number of tokens used.
+func parseCssPosition(tokens []cssToken) ([2]CssLength, int, bool) {
+	pos := [2]CssLength{{50, "%"}, {50, "%"}}
+	vals := []CssLength{}
+	axes := []float64{}
+
+	for _, t := range tokens {
+		tok := t.s
+		if len(vals) == 2 {
+			break
+		}
+		if kw, ok := cssPositionKeywords[strings.ToLower(tok)]; ok {
+			vals = append(vals, CssLength{kw[0], "%"})
+			axes = append(axes, kw[1])
+			continue
+		}
+		l, ok := parseCssLength(tok)
+		if !ok {
+			break
+		}
+		vals = append(vals, l)
+		axes = append(axes, 0)
+		if len(vals) == 2 {
+			axes[1] = 1
+		}
+	}
+
+	switch len(vals) {
+	case 1:
+		if axes[0] == 1 {
+			pos[1] = vals[0]
+		} else {
+			pos[0] = vals[0]
+		}
+	case 2:
+		if axes[0] == 1 || axes[1] == 0 {
+			if axes[0] == 0 || axes[1] == 1 {
+				return pos, 0, false
+			}
+			vals[0], vals[1] = vals[1], vals[0]
+		}
+		pos[0] = vals[0]
+		pos[1] = vals[1]
+	default:
+		return pos, 0, false
+	}
+	return pos, len(vals), true
+}
+
+// Absolute CSS length units in px
+var cssLengthUnits = map[string]float64{
+	"px": 1,
+	"cm": 96 / 2.54,
+	"mm": 96 / 25.4,
+	"q":  96 / 101.6,
+	"in": 96,
+	"pt": 96.0 / 72,
+	"pc": 16,
+}
+
+func parseCssLength(s string) (CssLength, bool) {
+	s = strings.ToLower(s)
+	if strings.HasSuffix(s, "%") {
+		f, ok := parseFloat(s[:len(s)-1])
+		return CssLength{f, "%"}, ok
+	}
+	for unit, px := range cssLengthUnits {
+		if strings.HasSuffix(s, unit) {
+			f, ok := parseFloat(s[:len(s)-len(unit)])
+			return CssLength{f * px, "px"}, ok
+		}
+	}
+	if f, ok := parseFloat(s); ok && f == 0 {
+		return CssLength{0, "px"}, true
+	}
+	return CssLength{}, false
+}
+
+// Parse a CSS <angle> to degrees
+func parseAngle(s string) (float64, bool) {
+	s = strings.ToLower(s)
+	units := []struct {
+		unit  string
+		scale float64
+	}{
+		{"deg", 1},
+		{"grad", 0.9},
+		{"rad", 180 / math.Pi},
+		{"turn", 360},
+	}
+	for _, u := range units {
+		if strings.HasSuffix(s, u.unit) {
+			f, ok := parseFloat(s[:len(s)-len(u.unit)])
+			return f * u.scale, ok
+		}
+	}
+	if f, ok := parseFloat(s); ok && f == 0 {
+		return 0, true
+	}
+	return 0, false
+}
+
+func parseCssStops(args []cssToken, kind CssGradientKind, mode BlendMode, hue HueInterpolation) ([]cssGradientStop, error) {
 	stops := []cssGradientStop{}
 
-	for _, stop := range splitByComma(s) {
-		if !prosesStop(&stops, splitBySpace(stop)) {
-			return stops, false
+	for _, arg := range args {
+		if err := prosesStop(&stops, arg, kind); err != nil {
+			return stops, err
 		}
 	}
 
 	if len(stops) == 0 {
-		return stops, false
+		return stops, &CssGradientError{Reason: "no color stops"}
 	}
 
-	if stops[0].color == nil {
-		return stops, false
+	for i, stop := range stops {
+		if stop.color != nil {
+			continue
+		}
+		if i == 0 || i == len(stops)-1 || stops[i+1].color == nil {
+			return stops, cssError(stop.tok, "transition hint must be between two color stops")
+		}
+	}
+
+	// Positions given as lengths can't be mixed with percentages, the
+	// length of the gradient line is unknown.
+	length := false
+	for i, stop := range stops {
+		if stop.pos == nil {
+			continue
+		}
+		if stop.length && !length {
+			for _, st := range stops[:i] {
+				if st.pos != nil && *st.pos != 0 {
+					return stops, cssError(stop.tok, "length mixed with percentage positions")
+				}
+			}
+			length = true
+		} else if length && !stop.length && *stop.pos != 0 {
+			return stops, cssError(stop.tok, "percentage mixed with length positions")
+		}
+	}
+	for i := range stops {
+		stops[i].length = length
 	}
 
 	if stops[0].pos == nil {
 		stops[0].pos = ptr(0.0)
 	}
 
-	for i, stop := range stops {
-		if i == len(stops)-1 {
-			if stop.pos == nil {
-				stops[i].pos = ptr(1.0)
-			}
-			break
+	last := len(stops) - 1
+	if stops[last].pos == nil {
+		if length {
+			return stops, cssError(stops[last].tok, "missing position of the last color stop")
 		}
+		stops[last].pos = ptr(1.0)
+	}
 
+	for i, stop := range stops {
 		if stop.color == nil {
-			if stops[i+1].color == nil {
-				return stops, false
-			}
-			stops[i].color = ptrColor(blendRgb(*stops[i-1].color, *stops[i+1].color, 0.5))
+			stops[i].color = ptrColor(blendColors(*stops[i-1].color, *stops[i+1].color, mode, hue, 0.5))
 		}
 	}
 
 	if *stops[0].pos > 0.0 {
-		stops = append([]cssGradientStop{{ptr(0.0), stops[0].color}}, stops...)
+		stops = append([]cssGradientStop{{ptr(0.0), stops[0].color, length, stops[0].tok}}, stops...)
 	}
 
-	if *stops[len(stops)-1].pos < 1.0 {
-		stops = append(stops, cssGradientStop{ptr(1.0), stops[len(stops)-1].color})
+	if !length && *stops[len(stops)-1].pos < 1.0 {
+		stops = append(stops, cssGradientStop{ptr(1.0), stops[len(stops)-1].color, length, stops[len(stops)-1].tok})
 	}
 
 	for i, stop := range stops {
@@ -69,13 +586,7 @@ func parseCss(s string) ([]cssGradientStop, bool) {
 		}
 	}
 
-	for _, stop := range stops {
-		if stop.color == nil || stop.pos == nil {
-			return stops, false
-		}
-	}
-
-	return stops, true
+	return stops, nil
 }
 
 func ptr(f float64) *float64 {
@@ -89,99 +600,92 @@ func ptrColor(c Color) *Color {
 type cssGradientStop struct {
 	pos   *float64
 	color *Color
+	// Position is a length in px instead of a fraction
+	length bool
+	tok    cssToken
 }
 
-func prosesStop(stops *[]cssGradientStop, arr []string) bool {
+func prosesStop(stops *[]cssGradientStop, arg cssToken, kind CssGradientKind) error {
+	arr := splitBySpace(arg)
+
 	switch len(arr) {
+	case 0:
+		return cssError(arg, "empty color stop")
 	case 1:
-		col, err := csscolorparser.Parse(arr[0])
+		col, err := csscolorparser.Parse(arr[0].s)
 		if err == nil {
-			*stops = append(*stops, cssGradientStop{nil, &col})
-			return true
+			*stops = append(*stops, cssGradientStop{nil, &col, false, arr[0]})
+			return nil
 		}
 
-		pos, ok := parsePos(arr[0])
+		pos, length, ok := parseStopPos(arr[0].s, kind)
 		if ok {
-			*stops = append(*stops, cssGradientStop{&pos, nil})
-			return true
+			*stops = append(*stops, cssGradientStop{&pos, nil, length, arr[0]})
+			return nil
 		}
-		return false
-	case 2:
-		col, err := csscolorparser.Parse(arr[0])
+		return cssError(arr[0], "invalid color or position")
+	case 2, 3:
+		col, err := csscolorparser.Parse(arr[0].s)
 		if err != nil {
-			return false
-		}
-
-		pos, ok := parsePos(arr[1])
-		if !ok {
-			return false
+			return cssError(arr[0], "invalid color")
 		}
 
-		*stops = append(*stops, cssGradientStop{&pos, &col})
-	case 3:
-		col, err := csscolorparser.Parse(arr[0])
-		if err != nil {
-			return false
-		}
-
-		pos1, ok1 := parsePos(arr[1])
-		if !ok1 {
-			return false
-		}
-
-		pos2, ok2 := parsePos(arr[2])
-		if !ok2 {
-			return false
+		for _, tok := range arr[1:] {
+			pos, length, ok := parseStopPos(tok.s, kind)
+			if !ok {
+				return cssError(tok, "invalid position")
+			}
+			*stops = append(*stops, cssGradientStop{&pos, &col, length, tok})
 		}
-
-		*stops = append(*stops, cssGradientStop{&pos1, &col})
-		*stops = append(*stops, cssGradientStop{&pos2, &col})
 	default:
-		return false
+		return cssError(arr[3], "unexpected token")
 	}
-	return true
+	return nil
 }
 
-func splitByComma(s string) []string {
-	res := []string{}
+func splitByComma(s cssToken) []cssToken {
+	res := []cssToken{}
 	beg := 0
-	inside := false
+	depth := 0
 
-	for i := 0; i < len(s); i++ {
-		if s[i] == ',' && !inside {
-			res = append(res, s[beg:i])
+	for i := 0; i < len(s.s); i++ {
+		if s.s[i] == ',' && depth == 0 {
+			res = append(res, cssToken{s.s[beg:i], s.pos + beg})
 			beg = i + 1
-		} else if s[i] == '(' {
-			inside = true
-		} else if s[i] == ')' {
-			inside = false
+		} else if s.s[i] == '(' {
+			depth++
+		} else if s.s[i] == ')' && depth > 0 {
+			depth--
 		}
 	}
-	return append(res, s[beg:])
+	return append(res, cssToken{s.s[beg:], s.pos + beg})
 }
 
-func splitBySpace(s string) []string {
-	res := []string{}
+func splitBySpace(s cssToken) []cssToken {
+	res := []cssToken{}
 	beg := 0
-	inside := false
+	depth := 0
 
-	for i := 0; i < len(s); i++ {
-		if s[i] == ' ' && !inside {
-			if len(s[beg:i]) > 0 {
-				res = append(res, s[beg:i])
+	for i := 0; i < len(s.s); i++ {
+		if isSpace(s.s[i]) && depth == 0 {
+			if len(s.s[beg:i]) > 0 {
+				res = append(res, cssToken{s.s[beg:i], s.pos + beg})
 			}
 			beg = i + 1
-		} else if s[i] == '(' {
-			inside = true
-		} else if s[i] == ')' {
-			inside = false
+		} else if s.s[i] == '(' {
+			depth++
+		} else if s.s[i] == ')' && depth > 0 {
+			depth--
 		}
 	}
-	if len(s[beg:]) > 0 {
-		res = append(res, s[beg:])
+	if len(s.s[beg:]) > 0 {
+		res = append(res, cssToken{s.s[beg:], s.pos + beg})
 	}
 	return res
 }
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
 
 func parsePos(s string) (float64, bool) {
 	if strings.HasSuffix(s, "%") {
@@ -195,3 +699,30 @@ func parsePos(s string) (float64, bool) {
 	f, ok := parseFloat(s)
 	return f, ok
 }
+
+// Parse a color stop or hint position. Conic gradients take angles, the
+// others take lengths, which are returned in px.
+func parseStopPos(s string, kind CssGradientKind) (float64, bool, bool) {
+	if pos, ok := parsePos(s); ok {
+		return pos, false, true
+	}
+	if kind == CssConicGradient {
+		if angle, ok := parseAngle(s); ok {
+			return angle / 360, false, true
+		}
+		return 0, false, false
+	}
+	if kind != CssStopList {
+		if l, ok := parseCssLength(s); ok && l.Unit == "px" {
+			return l.Value, true, true
+		}
+	}
+	return 0, false, false
+}
+
+// Blend two colors in the given color space
+func blendColors(a, b Color, mode BlendMode, hue HueInterpolation, t float64) Color {
+	arr := convertColors([]Color{a, b}, mode, hue)
+	i, j, k, l := linearInterpolate(arr[0], arr[1], t)
+	return colorFromSpace(mode, i, j, k, l)
+}
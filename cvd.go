@@ -0,0 +1,227 @@
+package colorgrad
+
+import (
+	"math"
+)
+
+// Color vision deficiency
+type Deficiency int
+
+const (
+	// Missing or anomalous L (red) cones
+	Protanopia Deficiency = iota
+	// Missing or anomalous M (green) cones
+	Deuteranopia
+	// Missing or anomalous S (blue) cones
+	Tritanopia
+)
+
+func (d Deficiency) String() string {
+	switch d {
+	case Protanopia:
+		return "Protanopia"
+	case Deuteranopia:
+		return "Deuteranopia"
+	case Tritanopia:
+		return "Tritanopia"
+	}
+	return ""
+}
+
+type CvdMethod int
+
+const (
+	// Machado, Oliveira, Fernandes (2009), a single linear RGB matrix
+	CvdMachado CvdMethod = iota
+	// Brettel, Viénot, Mollon (1997), two half-planes in LMS
+	CvdBrettel
+)
+
+func (m CvdMethod) String() string {
+	switch m {
+	case CvdMachado:
+		return "CvdMachado"
+	case CvdBrettel:
+		return "CvdBrettel"
+	}
+	return ""
+}
+
+// Simulation of a color vision deficiency
+type Cvd struct {
+	Deficiency Deficiency
+	// 0 is normal vision, 1 is dichromacy (e.g. protanopia), in between is
+	// anomalous trichromacy (e.g. protanomaly). The simulated color is
+	// interpolated in linear RGB between the color and its dichromat version.
+	Severity float64
+	Method   CvdMethod
+}
+
+// Reference: https://www.inf.ufrgs.br/~oliveira/pubs_files/CVD_Simulation/CVD_Simulation.html
+// Severity 1.0
+var machadoMatrices = [3]mat3{
+	{
+		0.152286, 1.052583, -0.204868,
+		0.114503, 0.786281, 0.099216,
+		-0.003882, -0.048116, 1.051998,
+	},
+	{
+		0.367322, 0.860646, -0.227968,
+		0.280085, 0.672501, 0.047413,
+		-0.011820, 0.042940, 0.968881,
+	},
+	{
+		1.255528, -0.076749, -0.178779,
+		-0.078411, 0.930809, 0.147602,
+		0.004733, 0.691367, 0.303900,
+	},
+}
+
+// Brettel's projections of linear RGB onto the two half-planes, and the
+// normal of the plane separating them. Precomputed from the sRGB to LMS
+// matrix of Viénot et al. as in libDaltonLens.
+// Reference: https://github.com/DaltonLens/libDaltonLens
+var brettelParams = [3]struct {
+	m1, m2 mat3
+	normal [3]float64
+}{
+	{
+		mat3{0.14980, 1.19548, -0.34528, 0.10764, 0.84864, 0.04372, 0.00384, -0.00540, 1.00156},
+		mat3{0.14570, 1.16172, -0.30742, 0.10816, 0.85291, 0.03892, 0.00386, -0.00524, 1.00139},
+		[3]float64{0.00048, 0.00393, -0.00441},
+	},
+	{
+		mat3{0.36477, 0.86381, -0.22858, 0.26294, 0.64245, 0.09462, -0.02006, 0.02728, 0.99278},
+		mat3{0.37298, 0.88166, -0.25464, 0.25954, 0.63506, 0.10540, -0.01980, 0.02784, 0.99196},
+		[3]float64{-0.00281, -0.00611, 0.00892},
+	},
+	{
+		mat3{1.01277, 0.13548, -0.14826, -0.01243, 0.86812, 0.14431, 0.07589, 0.80500, 0.11911},
+		mat3{0.93678, 0.18979, -0.12657, 0.06154, 0.81526, 0.12320, -0.37562, 1.12767, 0.24796},
+		[3]float64{0.03901, -0.02788, -0.01113},
+	},
+}
+
+// Color as seen with the deficiency. Alpha is kept.
+func (c Cvd) Color(col Color) Color {
+	d := int(c.Deficiency)
+	if d < 0 || d >= len(machadoMatrices) {
+		return col
+	}
+	severity := clamp01(c.Severity)
+	lin := col2linearRgb(col)
+
+	var sim [3]float64
+	if c.Method == CvdBrettel {
+		p := &brettelParams[d]
+		if p.normal[0]*lin[0]+p.normal[1]*lin[1]+p.normal[2]*lin[2] >= 0 {
+			sim = p.m1.mul(lin)
+		} else {
+			sim = p.m2.mul(lin)
+		}
+	} else {
+		sim = machadoMatrices[d].mul(lin)
+	}
+
+	for i := range sim {
+		sim[i] = clamp01(lin[i] + (sim[i]-lin[i])*severity)
+	}
+	return LinearRgb(sim[0], sim[1], sim[2], col.A)
+}
+
+type cvdGradient struct {
+	core GradientCore
+	cvd  Cvd
+}
+
+func (cg cvdGradient) At(t float64) Color {
+	return cg.cvd.Color(cg.core.At(t))
+}
+
+// Return the gradient as seen with a color vision deficiency. The NaN, under
+// and over colors are simulated too.
+func (g Gradient) SimulateCvd(c Cvd) Gradient {
+	simulate := func(col *Color) *Color {
+		if col == nil {
+			return nil
+		}
+		res := c.Color(*col)
+		return &res
+	}
+	return Gradient{
+		Core:  cvdGradient{g.Core, c},
+		Min:   g.Min,
+		Max:   g.Max,
+		NaN:   simulate(g.NaN),
+		Under: simulate(g.Under),
+		Over:  simulate(g.Over),
+	}
+}
+
+// Return the palette as seen with a color vision deficiency
+func (p Palette) SimulateCvd(c Cvd) Palette {
+	res := make(Palette, len(p))
+	for i, col := range p {
+		res[i] = c.Color(col)
+	}
+	return res
+}
+
+// Smallest CIEDE2000 difference between neighboring samples of a gradient
+// seen with a color vision deficiency
+type GradientCvdCheck struct {
+	Cvd       Cvd
+	MinDeltaE float64
+	// Positions of the two samples with the smallest difference
+	Start, End float64
+}
+
+// Check how distinguishable count colors evenly spaced across the gradient
+// remain with a color vision deficiency, e.g. the classes of a colorbar. A
+// MinDeltaE below about 1 can't be seen, gradients meant to be read in
+// steps usually need 5 or more. Alpha is ignored.
+func (g Gradient) CheckCvd(c Cvd, count uint) GradientCvdCheck {
+	if count < 2 {
+		count = 2
+	}
+	positions := linspace(g.Min, g.Max, count)
+	res := GradientCvdCheck{Cvd: c, MinDeltaE: math.Inf(1)}
+	prev := col2lab(c.Color(g.At(positions[0])))
+
+	for i := 1; i < len(positions); i++ {
+		cur := col2lab(c.Color(g.At(positions[i])))
+		if d := deltaE2000(prev, cur); d < res.MinDeltaE {
+			res.MinDeltaE, res.Start, res.End = d, positions[i-1], positions[i]
+		}
+		prev = cur
+	}
+	return res
+}
+
+// Smallest CIEDE2000 difference between two colors of a palette seen with a
+// color vision deficiency
+type PaletteCvdCheck struct {
+	Cvd       Cvd
+	MinDeltaE float64
+	// Indices of the two least distinguishable colors, I < J. Both are -1 for
+	// palettes with less than two colors.
+	I, J int
+}
+
+// Check how distinguishable every pair of colors of the palette remains with
+// a color vision deficiency. Alpha is ignored.
+func (p Palette) CheckCvd(c Cvd) PaletteCvdCheck {
+	res := PaletteCvdCheck{Cvd: c, MinDeltaE: math.Inf(1), I: -1, J: -1}
+	labs := make([][4]float64, len(p))
+	for i, col := range p {
+		labs[i] = col2lab(c.Color(col))
+	}
+	for i := range labs {
+		for j := i + 1; j < len(labs); j++ {
+			if d := deltaE2000(labs[i], labs[j]); d < res.MinDeltaE {
+				res.MinDeltaE, res.I, res.J = d, i, j
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,94 @@
+package colorgrad
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_Cvd(t *testing.T) {
+	red := Rgb(1, 0, 0, 1)
+
+	for _, m := range []CvdMethod{CvdMachado, CvdBrettel} {
+		for _, d := range []Deficiency{Protanopia, Deuteranopia, Tritanopia} {
+			c := Cvd{Deficiency: d, Severity: 1, Method: m}
+			// Neutral colors are kept
+			test(t, c.Color(Rgb(0, 0, 0, 1)).HexString(), "#000000")
+			test(t, c.Color(Rgb(1, 1, 1, 1)).HexString(), "#ffffff")
+			testTrue(t, DeltaE2000(c.Color(Rgb(0.5, 0.5, 0.5, 1)), Rgb(0.5, 0.5, 0.5, 1)) < 0.5)
+			// Normal vision
+			c.Severity = 0
+			test(t, c.Color(red).HexString(), "#ff0000")
+			c.Severity = -1
+			test(t, c.Color(red).HexString(), "#ff0000")
+		}
+	}
+
+	test(t, Cvd{Protanopia, 1, CvdMachado}.Color(red).HexString(), "#6d5f00")
+	test(t, Cvd{Deuteranopia, 1, CvdMachado}.Color(red).HexString(), "#a39000")
+	test(t, Cvd{Tritanopia, 1, CvdMachado}.Color(red).HexString(), "#ff000f")
+	test(t, Cvd{Protanopia, 1, CvdBrettel}.Color(red).HexString(), "#6c5c0c")
+	test(t, Cvd{Deuteranopia, 1, CvdBrettel}.Color(red).HexString(), "#a48b00")
+	test(t, Cvd{Tritanopia, 1, CvdBrettel}.Color(red).HexString(), "#ff004e")
+
+	// Alpha is kept
+	test(t, Cvd{Protanopia, 1, CvdMachado}.Color(Rgb(1, 0, 0, 0.5)).A, 0.5)
+
+	// Anomalous trichromacy is in between
+	full := DeltaE2000(red, Cvd{Deuteranopia, 1, CvdMachado}.Color(red))
+	half := DeltaE2000(red, Cvd{Deuteranopia, 0.5, CvdMachado}.Color(red))
+	testTrue(t, half > 0 && half < full)
+
+	// Unknown deficiency
+	test(t, Cvd{Deficiency: 5, Severity: 1}.Color(red), red)
+
+	test(t, Protanopia.String(), "Protanopia")
+	test(t, CvdBrettel.String(), "CvdBrettel")
+}
+
+func Test_GradientCvd(t *testing.T) {
+	grad, _ := NewGradient().
+		HtmlColors("#d62728", "#2ca02c").
+		Domain(0, 100).
+		Build()
+	grad.NaN = &Color{R: 1, A: 1}
+	c := Cvd{Deficiency: Protanopia, Severity: 1}
+
+	sim := grad.SimulateCvd(c)
+	test(t, sim.Min, 0.0)
+	test(t, sim.Max, 100.0)
+	test(t, sim.At(50), c.Color(grad.At(50)))
+	test(t, sim.At(math.NaN()).HexString(), "#6d5f00")
+	testTrue(t, sim.Under == nil && sim.Over == nil)
+
+	normal := grad.CheckCvd(Cvd{}, 5)
+	testTrue(t, normal.MinDeltaE > 5)
+
+	check := grad.CheckCvd(c, 5)
+	test(t, check.Cvd, c)
+	testTrue(t, check.MinDeltaE < 1)
+	test(t, check.Start, 0.0)
+	test(t, check.End, 25.0)
+
+	check = Viridis().CheckCvd(Cvd{Deficiency: Deuteranopia, Severity: 1}, 5)
+	testTrue(t, check.MinDeltaE > 10)
+}
+
+func Test_PaletteCvd(t *testing.T) {
+	p := Palette{Rgb(0, 0, 1, 1), Rgb(1, 0, 0, 1), Rgb(0, 0.5, 0, 1)}
+	c := Cvd{Deficiency: Deuteranopia, Severity: 1}
+
+	sim := p.SimulateCvd(c)
+	test(t, len(sim), 3)
+	test(t, sim[1].HexString(), "#a39000")
+
+	normal := p.CheckCvd(Cvd{})
+	check := p.CheckCvd(c)
+	testTrue(t, check.MinDeltaE < normal.MinDeltaE)
+	test(t, check.I, 1)
+	test(t, check.J, 2)
+
+	check = Palette{Rgb(1, 0, 0, 1)}.CheckCvd(c)
+	test(t, check.I, -1)
+	test(t, check.J, -1)
+	testTrue(t, math.IsInf(check.MinDeltaE, 1))
+}
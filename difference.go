@@ -0,0 +1,94 @@
+package colorgrad
+
+import "math"
+
+// Convert a color to CIE L*a*b* (D65 white point), L* is in [0..100]. Alpha
+// is ignored.
+func ToLab(col Color) (l, a, b float64) {
+	lab := col2lab(col)
+	return lab[0], lab[1], lab[2]
+}
+
+// Convert a color to Oklab, L is in [0..1]. Alpha is ignored.
+func ToOklab(col Color) (l, a, b float64) {
+	lab := col2oklab(col)
+	return lab[0], lab[1], lab[2]
+}
+
+// CIEDE2000 color difference between two colors, computed in CIE L*a*b*. A
+// difference of about 1 is just noticeable. Alpha is ignored.
+func DeltaE2000(a, b Color) float64 {
+	return deltaE2000(col2lab(a), col2lab(b))
+}
+
+// Euclidean distance between two colors in Oklab. A difference of about 0.02
+// is just noticeable. Alpha is ignored.
+func DeltaEOk(a, b Color) float64 {
+	x, y := col2oklab(a), col2oklab(b)
+	return math.Sqrt(sq(x[0]-y[0]) + sq(x[1]-y[1]) + sq(x[2]-y[2]))
+}
+
+func sq(v float64) float64 {
+	return v * v
+}
+
+// Reference: Sharma, Wu, Dalal, "The CIEDE2000 Color-Difference Formula:
+// Implementation Notes, Supplementary Test Data, and Mathematical
+// Observations" (2005)
+func deltaE2000(lab1, lab2 [4]float64) float64 {
+	const deg = math.Pi / 180
+	pow25 := math.Pow(25, 7)
+
+	l1, a1, b1 := lab1[0], lab1[1], lab1[2]
+	l2, a2, b2 := lab2[0], lab2[1], lab2[2]
+
+	cMean := (math.Hypot(a1, b1) + math.Hypot(a2, b2)) / 2
+	g := 0.5 * (1 - math.Sqrt(math.Pow(cMean, 7)/(math.Pow(cMean, 7)+pow25)))
+	a1, a2 = a1*(1+g), a2*(1+g)
+
+	c1, c2 := math.Hypot(a1, b1), math.Hypot(a2, b2)
+	hue := func(a, b float64) float64 {
+		if a == 0 && b == 0 {
+			return 0
+		}
+		return modulo(math.Atan2(b, a)/deg, 360)
+	}
+	h1, h2 := hue(a1, b1), hue(a2, b2)
+
+	dl := l2 - l1
+	dc := c2 - c1
+	dh := 0.0
+	if c1*c2 != 0 {
+		dh = h2 - h1
+		if dh > 180 {
+			dh -= 360
+		} else if dh < -180 {
+			dh += 360
+		}
+	}
+	dhh := 2 * math.Sqrt(c1*c2) * math.Sin(dh/2*deg)
+
+	lMean := (l1 + l2) / 2
+	cMean = (c1 + c2) / 2
+	hMean := h1 + h2
+	if c1*c2 != 0 {
+		if math.Abs(h1-h2) <= 180 {
+			hMean /= 2
+		} else if hMean < 360 {
+			hMean = (hMean + 360) / 2
+		} else {
+			hMean = (hMean - 360) / 2
+		}
+	}
+
+	t := 1 - 0.17*math.Cos((hMean-30)*deg) + 0.24*math.Cos(2*hMean*deg) +
+		0.32*math.Cos((3*hMean+6)*deg) - 0.20*math.Cos((4*hMean-63)*deg)
+	dTheta := 30 * math.Exp(-sq((hMean-275)/25))
+	rc := 2 * math.Sqrt(math.Pow(cMean, 7)/(math.Pow(cMean, 7)+pow25))
+	sl := 1 + 0.015*sq(lMean-50)/math.Sqrt(20+sq(lMean-50))
+	sc := 1 + 0.045*cMean
+	sh := 1 + 0.015*cMean*t
+	rt := -math.Sin(2*dTheta*deg) * rc
+
+	return math.Sqrt(sq(dl/sl) + sq(dc/sc) + sq(dhh/sh) + rt*(dc/sc)*(dhh/sh))
+}
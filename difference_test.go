@@ -0,0 +1,43 @@
+package colorgrad
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_DeltaE2000(t *testing.T) {
+	// Pairs from the supplementary test data of Sharma et al.
+	data := [][7]float64{
+		{50, 2.6772, -79.7751, 50, 0, -82.7485, 2.0425},
+		{50, 3.1571, -77.2803, 50, 0, -82.7485, 2.8615},
+		{50, 2.8361, -74.0200, 50, 0, -82.7485, 3.4412},
+		{50, 0, 0, 50, -1, 2, 2.3669},
+		{50, 2.5, 0, 73, 25, -18, 27.1492},
+		{50, 2.5, 0, 56, -27, -3, 31.9030},
+		{60.2574, -34.0099, 36.2677, 60.4626, -34.1751, 39.4387, 1.2644},
+		{2.0776, 0.0795, -1.1350, 0.9033, -0.0636, -0.5514, 0.9082},
+	}
+	for _, d := range data {
+		de := deltaE2000([4]float64{d[0], d[1], d[2]}, [4]float64{d[3], d[4], d[5]})
+		if math.Abs(de-d[6]) > 1e-4 {
+			t.Errorf("%v: %v", d, de)
+		}
+		de2 := deltaE2000([4]float64{d[3], d[4], d[5]}, [4]float64{d[0], d[1], d[2]})
+		if math.Abs(de-de2) > 1e-9 {
+			t.Errorf("not symmetric %v: %v %v", d, de, de2)
+		}
+	}
+
+	black := Rgb(0, 0, 0, 1)
+	white := Rgb(1, 1, 1, 1)
+	test(t, DeltaE2000(white, white), 0.0)
+	testTrue(t, math.Abs(DeltaE2000(black, white)-100) < 1e-3)
+	test(t, DeltaEOk(white, white), 0.0)
+	testTrue(t, math.Abs(DeltaEOk(black, white)-1) < 1e-3)
+	testTrue(t, DeltaEOk(Rgb(1, 0, 0, 1), Rgb(1, 0, 0, 0)) == 0)
+
+	l, a, b := ToLab(white)
+	testTrue(t, math.Abs(l-100) < 1e-3 && math.Abs(a) < 1e-3 && math.Abs(b) < 1e-3)
+	l, a, b = ToOklab(Rgb(0.5, 0.5, 0.5, 1))
+	testTrue(t, l > 0.59 && l < 0.6 && math.Abs(a) < 1e-3 && math.Abs(b) < 1e-3)
+}
@@ -0,0 +1,274 @@
+package colorgrad
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Stops of g for formats interpolating linearly in RGB: the exact stops of
+// gradients which are piecewise linear in RGB, n evenly spaced samples
+// otherwise.
+func rgbStops(g Gradient, n int) ([]Color, []float64) {
+	if colors, positions, ok := exactStops(g); ok {
+		return colors, positions
+	}
+	return g.Colors(uint(n)), linspace(g.Min, g.Max, uint(n))
+}
+
+type CssOptions struct {
+	// Direction of the gradient line, e.g. "to right" or "45deg", omitted if
+	// empty
+	Direction string
+	// Write linear gradients blended in any mode but BlendHsv with their exact
+	// stops, using a color interpolation method (e.g. "in oklab") from CSS
+	// Color Module Level 4. Otherwise only gradients blended in RGB are exact.
+	ColorSpace bool
+	// Number of stops sampled from gradients which can't be written exactly.
+	// Default is 16.
+	Stops int
+}
+
+// WriteCss writes g as a CSS linear-gradient() function. The domain of g is
+// mapped to [0%..100%].
+func WriteCss(w io.Writer, g Gradient, opts CssOptions) error {
+	if opts.Stops < 2 {
+		opts.Stops = 16
+	}
+
+	colors, positions := rgbStops(g, opts.Stops)
+	method := ""
+
+	if core, ok := g.Core.(linearGradient); ok && opts.ColorSpace && core.mode != BlendRgb {
+		for name, mode := range cssColorSpaces {
+			if mode == core.mode {
+				method = "in " + name
+			}
+		}
+		if method != "" {
+			colors = core.input
+			positions = core.positions
+			if hueIndex(core.mode) >= 0 && core.hue != HueShorter {
+				for name, hue := range cssHueMethods {
+					if hue == core.hue {
+						method += " " + name + " hue"
+					}
+				}
+			}
+		}
+	}
+
+	args := []string{}
+	if prelude := strings.TrimSpace(opts.Direction + " " + method); prelude != "" {
+		args = append(args, prelude)
+	}
+	for i, col := range colors {
+		offset := norm(positions[i], g.Min, g.Max) * 100
+		args = append(args, col.Clamp().HexString()+" "+formatFloat(offset, 4)+"%")
+	}
+
+	_, err := fmt.Fprintf(w, "linear-gradient(%s)", strings.Join(args, ", "))
+	return err
+}
+
+type ShaderLanguage int
+
+const (
+	ShaderGlsl ShaderLanguage = iota
+	ShaderHlsl
+)
+
+func (l ShaderLanguage) String() string {
+	switch l {
+	case ShaderGlsl:
+		return "ShaderGlsl"
+	case ShaderHlsl:
+		return "ShaderHlsl"
+	}
+	return ""
+}
+
+type ShaderOptions struct {
+	Language ShaderLanguage
+	// Name of the function. Default is "gradient".
+	Name string
+	// Number of stops sampled from gradients which can't be written exactly.
+	// Default is 16.
+	Stops int
+}
+
+// WriteShader writes g as a GLSL (vec4 name(float t)) or HLSL (float4
+// name(float t)) function returning the non-premultiplied color at t, with
+// the domain of g mapped to [0..1]. The stops are stored in constant arrays
+// and interpolated in RGB, so linear gradients blended in RGB, and sharp
+// gradients, are exact and other gradients are sampled.
+func WriteShader(w io.Writer, g Gradient, opts ShaderOptions) error {
+	if opts.Name == "" {
+		opts.Name = "gradient"
+	}
+	if opts.Stops < 2 {
+		opts.Stops = 16
+	}
+
+	colors, positions := rgbStops(g, opts.Stops)
+	n := len(colors)
+
+	pos := make([]string, n)
+	cols := make([]string, n)
+	vec4 := "vec4"
+	if opts.Language == ShaderHlsl {
+		vec4 = "float4"
+	}
+	for i, col := range colors {
+		col = col.Clamp()
+		pos[i] = shaderFloat(norm(positions[i], g.Min, g.Max))
+		cols[i] = fmt.Sprintf("%s(%s, %s, %s, %s)", vec4, shaderFloat(col.R), shaderFloat(col.G), shaderFloat(col.B), shaderFloat(col.A))
+	}
+
+	bw := bufio.NewWriter(w)
+	if opts.Language == ShaderHlsl {
+		fmt.Fprintf(bw, "float4 %s(float t) {\n", opts.Name)
+		fmt.Fprintf(bw, "    static const float pos[%d] = { %s };\n", n, strings.Join(pos, ", "))
+		fmt.Fprintf(bw, "    static const float4 col[%d] = {\n        %s\n    };\n", n, strings.Join(cols, ",\n        "))
+		bw.WriteString("    t = saturate(t);\n")
+	} else {
+		fmt.Fprintf(bw, "vec4 %s(float t) {\n", opts.Name)
+		fmt.Fprintf(bw, "    const float pos[%d] = float[%d](%s);\n", n, n, strings.Join(pos, ", "))
+		fmt.Fprintf(bw, "    const vec4 col[%d] = vec4[%d](\n        %s\n    );\n", n, n, strings.Join(cols, ",\n        "))
+		bw.WriteString("    t = clamp(t, 0.0, 1.0);\n")
+	}
+	mix := "mix"
+	if opts.Language == ShaderHlsl {
+		mix = "lerp"
+	}
+	fmt.Fprintf(bw, "    for (int i = 1; i < %d; i++) {\n", n)
+	bw.WriteString("        if (t <= pos[i]) {\n")
+	fmt.Fprintf(bw, "            return %s(col[i - 1], col[i], (t - pos[i - 1]) / max(pos[i] - pos[i - 1], 1e-6));\n", mix)
+	bw.WriteString("        }\n    }\n")
+	fmt.Fprintf(bw, "    return col[%d];\n}\n", n-1)
+	return bw.Flush()
+}
+
+// Shortest float literal reading back as the same 32-bit float, with a
+// decimal point or an exponent
+func shaderFloat(v float64) string {
+	s := strconv.FormatFloat(v, 'g', -1, 32)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// Shortest representation which reads back exactly
+func jsonFloat(v float64) json.Number {
+	return json.Number(strconv.FormatFloat(v, 'g', -1, 64))
+}
+
+type JsonOptions struct {
+	// Number of stops sampled from gradients which can't be written exactly.
+	// Default is 16.
+	Stops int
+}
+
+type jsonStop struct {
+	Position json.Number `json:"position"`
+	Color    string      `json:"color"`
+}
+
+type jsonGradient struct {
+	Domain [2]json.Number `json:"domain"`
+	Stops  []jsonStop     `json:"stops"`
+	NaN    string         `json:"nan,omitempty"`
+	Under  string         `json:"under,omitempty"`
+	Over   string         `json:"over,omitempty"`
+}
+
+// WriteJson writes g as a JSON object with the domain, the color stops
+// (positions in the domain, hex colors), to be interpolated in RGB, and the
+// NaN, under and over colors if set. Linear gradients blended in RGB, and
+// sharp gradients, are written exactly and other gradients are sampled.
+func WriteJson(w io.Writer, g Gradient, opts JsonOptions) error {
+	if opts.Stops < 2 {
+		opts.Stops = 16
+	}
+
+	colors, positions := rgbStops(g, opts.Stops)
+	doc := jsonGradient{
+		Domain: [2]json.Number{jsonFloat(g.Min), jsonFloat(g.Max)},
+		Stops:  make([]jsonStop, len(colors)),
+	}
+	for i, col := range colors {
+		doc.Stops[i] = jsonStop{jsonFloat(positions[i]), col.Clamp().HexString()}
+	}
+	hex := func(c *Color) string {
+		if c == nil {
+			return ""
+		}
+		return c.Clamp().HexString()
+	}
+	doc.NaN, doc.Under, doc.Over = hex(g.NaN), hex(g.Under), hex(g.Over)
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+type ArrayOptions struct {
+	// Name of the variable. Default is "gradient".
+	Name string
+	// Number of colors. Default is 256.
+	Size int
+	// Write RGBA instead of RGB values
+	Alpha bool
+}
+
+// WriteCArray writes Size colors evenly spaced across g as a C array of 8-bit
+// non-premultiplied RGB or RGBA values, e.g.
+//
+//	static const unsigned char gradient[256][3] = {
+//	    {0x44, 0x01, 0x54},
+//	    ...
+//	};
+func WriteCArray(w io.Writer, g Gradient, opts ArrayOptions) error {
+	return writeArray(w, g, opts, "static const unsigned char %s[%d][%d] = {\n", "    ", "};\n")
+}
+
+// WriteGoArray writes Size colors evenly spaced across g as a Go array of 8-bit
+// non-premultiplied RGB or RGBA values, e.g.
+//
+//	var gradient = [256][3]uint8{
+//		{0x44, 0x01, 0x54},
+//		...
+//	}
+func WriteGoArray(w io.Writer, g Gradient, opts ArrayOptions) error {
+	return writeArray(w, g, opts, "var %s = [%d][%d]uint8{\n", "\t", "}\n")
+}
+
+func writeArray(w io.Writer, g Gradient, opts ArrayOptions, header, indent, footer string) error {
+	if opts.Name == "" {
+		opts.Name = "gradient"
+	}
+	if opts.Size < 1 {
+		opts.Size = 256
+	}
+	channels := 3
+	if opts.Alpha {
+		channels = 4
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, header, opts.Name, opts.Size, channels)
+	for _, col := range g.Colors(uint(opts.Size)) {
+		r, gr, b, a := col.RGBA255()
+		if opts.Alpha {
+			fmt.Fprintf(bw, "%s{0x%02x, 0x%02x, 0x%02x, 0x%02x},\n", indent, r, gr, b, a)
+		} else {
+			fmt.Fprintf(bw, "%s{0x%02x, 0x%02x, 0x%02x},\n", indent, r, gr, b)
+		}
+	}
+	bw.WriteString(footer)
+	return bw.Flush()
+}
@@ -0,0 +1,194 @@
+package colorgrad
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func Test_WriteCss(t *testing.T) {
+	var sb strings.Builder
+
+	grad, _ := NewGradient().
+		HtmlColors("#f00", "#00ff0080", "#00f").
+		Domain(-1, 0, 3).
+		Build()
+
+	err := WriteCss(&sb, grad, CssOptions{Direction: "to right"})
+	test(t, err, nil)
+	test(t, sb.String(), "linear-gradient(to right, #ff0000 0%, #00ff0080 25%, #0000ff 100%)")
+
+	sb.Reset()
+	err = WriteCss(&sb, Viridis(), CssOptions{Stops: 3})
+	test(t, err, nil)
+	test(t, sb.String(), "linear-gradient(#440154 0%, #27838e 50%, #fee825 100%)")
+
+	// Exact in the color space of the gradient
+	for _, mode := range []BlendMode{BlendLinearRgb, BlendOklab, BlendLab, BlendOklch, BlendHsl, BlendHwb} {
+		for _, hue := range []HueInterpolation{HueShorter, HueLonger, HueDecreasing} {
+			grad, err = NewGradient().
+				HtmlColors("#f00", "#ffd700", "#1e90ff", "#ffffff").
+				Domain(0, 0.2, 0.7, 1).
+				Mode(mode).
+				HueInterpolation(hue).
+				Build()
+			test(t, err, nil)
+
+			sb.Reset()
+			err = WriteCss(&sb, grad, CssOptions{ColorSpace: true})
+			test(t, err, nil)
+			testTrue(t, strings.HasPrefix(sb.String(), "linear-gradient(in "))
+			grad2, err := NewGradient().Css(sb.String()).Build()
+			test(t, err, nil)
+			testSlice(t, colors2hex(grad2.Colors(21)), colors2hex(grad.Colors(21)))
+		}
+	}
+
+	// No CSS color space
+	grad, _ = NewGradient().HtmlColors("#f00", "#00f").Mode(BlendHsv).Build()
+	sb.Reset()
+	err = WriteCss(&sb, grad, CssOptions{ColorSpace: true, Stops: 3})
+	test(t, err, nil)
+	test(t, sb.String(), "linear-gradient(#ff0000 0%, #ff00ff 50%, #0000ff 100%)")
+}
+
+func Test_WriteCssBuilderReuse(t *testing.T) {
+	var sb strings.Builder
+
+	gb := NewGradient()
+	grad, _ := gb.HtmlColors("#f00", "#00f").Mode(BlendOklab).Build()
+	gb.Reset().HtmlColors("#0f0", "#ff0").Domain(5, 6).Build()
+
+	err := WriteCss(&sb, grad, CssOptions{ColorSpace: true})
+	test(t, err, nil)
+	test(t, sb.String(), "linear-gradient(in oklab, #ff0000 0%, #0000ff 100%)")
+	test(t, domain(grad.Domain()), [2]float64{0, 1})
+	test(t, grad.At(1).HexString(), "#0000ff")
+}
+
+func Test_WriteShader(t *testing.T) {
+	var sb strings.Builder
+
+	grad, _ := NewGradient().
+		HtmlColors("#f00", "#00ff0080").
+		Build()
+
+	err := WriteShader(&sb, grad, ShaderOptions{})
+	test(t, err, nil)
+	test(t, sb.String(), `vec4 gradient(float t) {
+    const float pos[2] = float[2](0.0, 1.0);
+    const vec4 col[2] = vec4[2](
+        vec4(1.0, 0.0, 0.0, 1.0),
+        vec4(0.0, 1.0, 0.0, 0.5019608)
+    );
+    t = clamp(t, 0.0, 1.0);
+    for (int i = 1; i < 2; i++) {
+        if (t <= pos[i]) {
+            return mix(col[i - 1], col[i], (t - pos[i - 1]) / max(pos[i] - pos[i - 1], 1e-6));
+        }
+    }
+    return col[1];
+}
+`)
+
+	sb.Reset()
+	err = WriteShader(&sb, Viridis(), ShaderOptions{Language: ShaderHlsl, Name: "viridis", Stops: 3})
+	test(t, err, nil)
+	test(t, sb.String(), `float4 viridis(float t) {
+    static const float pos[3] = { 0.0, 0.5, 1.0 };
+    static const float4 col[3] = {
+        float4(0.26666668, 0.003921569, 0.32941177, 1.0),
+        float4(0.151634, 0.5124183, 0.5568628, 1.0),
+        float4(0.99607843, 0.9098039, 0.14509805, 1.0)
+    };
+    t = saturate(t);
+    for (int i = 1; i < 3; i++) {
+        if (t <= pos[i]) {
+            return lerp(col[i - 1], col[i], (t - pos[i - 1]) / max(pos[i] - pos[i - 1], 1e-6));
+        }
+    }
+    return col[2];
+}
+`)
+}
+
+func Test_WriteJson(t *testing.T) {
+	var sb strings.Builder
+
+	grad, _ := NewGradient().
+		HtmlColors("#f00", "#00ff0080", "#00f").
+		Domain(-1, 0, 3).
+		OverColor(Rgb(1, 1, 1, 1)).
+		Build()
+
+	err := WriteJson(&sb, grad, JsonOptions{})
+	test(t, err, nil)
+
+	var doc struct {
+		Domain []float64
+		Stops  []struct {
+			Position float64
+			Color    string
+		}
+		NaN   *string
+		Under *string
+		Over  *string
+	}
+	err = json.Unmarshal([]byte(sb.String()), &doc)
+	test(t, err, nil)
+	testSlice(t, doc.Domain, []float64{-1, 3})
+	test(t, len(doc.Stops), 3)
+	test(t, doc.Stops[1].Position, 0.0)
+	test(t, doc.Stops[1].Color, "#00ff0080")
+	testTrue(t, doc.NaN == nil && doc.Under == nil)
+	test(t, *doc.Over, "#ffffff")
+
+	sb.Reset()
+	err = WriteJson(&sb, Viridis(), JsonOptions{Stops: 5})
+	test(t, err, nil)
+	err = json.Unmarshal([]byte(sb.String()), &doc)
+	test(t, err, nil)
+	test(t, len(doc.Stops), 5)
+	test(t, doc.Stops[4].Color, "#fee825")
+
+	// Small domains are written exactly
+	sb.Reset()
+	grad, _ = NewGradient().Domain(1e-7, 3e-7).Build()
+	err = WriteJson(&sb, grad, JsonOptions{})
+	test(t, err, nil)
+	err = json.Unmarshal([]byte(sb.String()), &doc)
+	test(t, err, nil)
+	testSlice(t, doc.Domain, []float64{1e-7, 3e-7})
+	test(t, doc.Stops[0].Position, 1e-7)
+}
+
+func Test_WriteArray(t *testing.T) {
+	var sb strings.Builder
+
+	grad, _ := NewGradient().
+		HtmlColors("#f00", "#00ff0080").
+		Build()
+
+	err := WriteCArray(&sb, grad, ArrayOptions{Size: 3})
+	test(t, err, nil)
+	test(t, sb.String(), `static const unsigned char gradient[3][3] = {
+    {0xff, 0x00, 0x00},
+    {0x80, 0x80, 0x00},
+    {0x00, 0xff, 0x00},
+};
+`)
+
+	sb.Reset()
+	err = WriteGoArray(&sb, grad, ArrayOptions{Name: "lut", Size: 2, Alpha: true})
+	test(t, err, nil)
+	test(t, sb.String(), `var lut = [2][4]uint8{
+	{0xff, 0x00, 0x00, 0xff},
+	{0x00, 0xff, 0x00, 0x80},
+}
+`)
+
+	sb.Reset()
+	err = WriteGoArray(&sb, grad, ArrayOptions{})
+	test(t, err, nil)
+	test(t, strings.Count(sb.String(), "\n"), 258)
+}
@@ -0,0 +1,170 @@
+package colorgrad
+
+import (
+	"math"
+)
+
+type GenerateOptions struct {
+	// Oklab lightness of the dark end, in [0..1]. Default is 0.3.
+	Dark float64
+	// Oklab lightness of the light end, or of the neutral midpoint of
+	// diverging gradients, in [0..1]. Default is 0.97.
+	Light float64
+	// Oklch chroma of the most saturated colors, before gamut mapping.
+	// Default is 0.15.
+	Chroma float64
+}
+
+func (o *GenerateOptions) defaults() {
+	if o.Dark <= 0 {
+		o.Dark = 0.3
+	}
+	if o.Light <= 0 {
+		o.Light = 0.97
+	}
+	if o.Chroma <= 0 {
+		o.Chroma = 0.15
+	}
+}
+
+type CyclicOptions struct {
+	// Oklab lightness of every color, in [0..1]. Default is 0.75.
+	Lightness float64
+	// Oklch chroma, before gamut mapping. Default is 0.12.
+	Chroma float64
+}
+
+// Colors along a path in Oklch, mapped into the sRGB gamut
+type oklchGradient struct {
+	// Oklch color (hue in degrees) and alpha at t in [0..1]
+	path func(t float64) [4]float64
+}
+
+func (og oklchGradient) At(t float64) Color {
+	if math.IsNaN(t) {
+		return Color{A: 1}
+	}
+	lch := og.path(clamp01(t))
+	return gamutMap(lch[0], lch[1], lch[2], lch[3])
+}
+
+func oklchGrad(path func(t float64) [4]float64) Gradient {
+	return Gradient{
+		Core: oklchGradient{path},
+		Min:  0,
+		Max:  1,
+	}
+}
+
+// Linear RGB of an Oklch color, hue in degrees
+func oklchToLinearRgb(l, c, h float64) [3]float64 {
+	return oklab2linearRgb(l, c*math.Cos(h*deg2rad), c*math.Sin(h*deg2rad))
+}
+
+func inGamut(rgb [3]float64) bool {
+	const eps = 1e-6
+	for _, v := range rgb {
+		if v < -eps || v > 1+eps {
+			return false
+		}
+	}
+	return true
+}
+
+// Color for an Oklch color, reducing the chroma until it is in the sRGB gamut
+// while keeping the lightness and hue
+func gamutMap(l, c, h, alpha float64) Color {
+	l = clamp01(l)
+	c = math.Max(0, c)
+	rgb := oklchToLinearRgb(l, c, h)
+
+	if !inGamut(rgb) {
+		low, high := 0.0, c
+		for high-low > 1e-5 {
+			mid := (low + high) / 2
+			if inGamut(oklchToLinearRgb(l, mid, h)) {
+				low = mid
+			} else {
+				high = mid
+			}
+		}
+		rgb = oklchToLinearRgb(l, low, h)
+	}
+	return LinearRgb(clamp01(rgb[0]), clamp01(rgb[1]), clamp01(rgb[2]), alpha)
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + t*(b-a)
+}
+
+// Sequential gradient of a single hue (Oklch, in degrees), from a light
+// almost neutral color to a dark saturated one. The lightness falls linearly
+// in Oklab from Light to Dark and the chroma rises from 0 to Chroma, reduced
+// where needed to stay in the sRGB gamut.
+func SequentialHue(hue float64, opts GenerateOptions) Gradient {
+	opts.defaults()
+	return oklchGrad(func(t float64) [4]float64 {
+		return [4]float64{lerp(opts.Light, opts.Dark, t), opts.Chroma * t, hue, 1}
+	})
+}
+
+// Sequential gradient from start to end with a linear lightness ramp: the
+// lightness, chroma and hue are interpolated linearly in Oklch, along the
+// shorter hue arc, and the colors are mapped into the sRGB gamut.
+func Sequential(start, end Color) Gradient {
+	lch := convertColors([]Color{start, end}, BlendOklch, HueShorter)
+	a, b := lch[0], lch[1]
+	return oklchGrad(func(t float64) [4]float64 {
+		return [4]float64{lerp(a[0], b[0], t), lerp(a[1], b[1], t), lerp(a[2], b[2], t), lerp(a[3], b[3], t)}
+	})
+}
+
+// Diverging gradient from a dark color of hue1 through a light neutral
+// midpoint to a dark color of hue2 (Oklch, in degrees). The lightness is
+// symmetric around the midpoint, rising linearly in Oklab from Dark to Light
+// and falling back, and the chroma falls linearly to 0 at the midpoint.
+func DivergingHue(hue1, hue2 float64, opts GenerateOptions) Gradient {
+	opts.defaults()
+	return oklchGrad(func(t float64) [4]float64 {
+		hue := hue1
+		if t > 0.5 {
+			hue = hue2
+		}
+		d := math.Abs(2*t - 1)
+		return [4]float64{lerp(opts.Light, opts.Dark, d), opts.Chroma * d, hue, 1}
+	})
+}
+
+// Diverging gradient from start through a light neutral midpoint (lightness
+// Light) to end. Both ends take the mean lightness of start and end, so the
+// lightness is symmetric around the midpoint, and keep their chroma and hue.
+// Dark and Chroma of opts are not used.
+func Diverging(start, end Color, opts GenerateOptions) Gradient {
+	opts.defaults()
+	a, b := col2oklch(start), col2oklch(end)
+	l := (a[0] + b[0]) / 2
+	return oklchGrad(func(t float64) [4]float64 {
+		side := a
+		if t > 0.5 {
+			side = b
+		}
+		d := math.Abs(2*t - 1)
+		return [4]float64{lerp(opts.Light, l, d), side[1] * d, side[2], lerp(a[3], b[3], t)}
+	})
+}
+
+// Cyclic gradient of constant lightness, with the hue rotating once around
+// the circle starting at hue (Oklch, in degrees), so both ends have the same
+// color. The chroma is reduced for the hues where it is out of the sRGB
+// gamut.
+func Cyclic(hue float64, opts CyclicOptions) Gradient {
+	if opts.Lightness <= 0 {
+		opts.Lightness = 0.75
+	}
+	if opts.Chroma <= 0 {
+		opts.Chroma = 0.12
+	}
+	return oklchGrad(func(t float64) [4]float64 {
+		return [4]float64{opts.Lightness, opts.Chroma, hue + 360*t, 1}
+	})
+}
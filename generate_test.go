@@ -0,0 +1,128 @@
+package colorgrad
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mazznoer/csscolorparser"
+)
+
+func oklabL(col Color) float64 {
+	return col2oklab(col)[0]
+}
+
+func testNear(t *testing.T, a, b, tolerance float64) {
+	t.Helper()
+	if math.Abs(a-b) > tolerance {
+		t.Errorf("left: %v, right: %v", a, b)
+	}
+}
+
+func Test_OklabRoundTrip(t *testing.T) {
+	for _, col := range []Color{Rgb(1, 0, 0, 1), Rgb(0, 1, 0, 1), Rgb(0, 0, 1, 1), Rgb(1, 1, 1, 1), Rgb(0.2, 0.7, 0.4, 1)} {
+		lab := col2oklab(col)
+		rgb := oklab2linearRgb(lab[0], lab[1], lab[2])
+		lin := col2linearRgb(col)
+		for i := range rgb {
+			testNear(t, rgb[i], lin[i], 1e-12)
+		}
+	}
+}
+
+func Test_GamutMap(t *testing.T) {
+	col := gamutMap(0.5, 0.4, 140, 1)
+	rgb := oklchToLinearRgb(0.5, 0.4, 140)
+	testTrue(t, !inGamut(rgb))
+	testNear(t, oklabL(col), 0.5, 1e-4)
+	testNear(t, col2oklch(col)[2], 140, 0.5)
+	testTrue(t, col2oklch(col)[1] < 0.4)
+
+	// In gamut colors are kept, the sRGB primaries and secondaries included
+	for _, s := range []string{"#ff0000", "#00ff00", "#0000ff", "#00ffff", "#ff00ff", "#ffff00", "#ffffff", "#000000", "#123456"} {
+		col, _ := csscolorparser.Parse(s)
+		lch := col2oklch(col)
+		test(t, gamutMap(lch[0], lch[1], lch[2], 1).HexString(), s)
+	}
+	test(t, gamutMap(0.627955, 0.257683, 29.2339, 1).HexString(), "#ff0000")
+	test(t, gamutMap(0, 0.2, 0, 1).HexString(), "#000000")
+	test(t, gamutMap(1, 0.2, 0, 0.5).HexString(), "#ffffff80")
+}
+
+func Test_Sequential(t *testing.T) {
+	grad := SequentialHue(250, GenerateOptions{})
+	test(t, grad.Min, 0.0)
+	test(t, grad.Max, 1.0)
+	for i, col := range grad.Colors(11) {
+		testNear(t, oklabL(col), 0.97+(0.3-0.97)*float64(i)/10, 1e-3)
+	}
+	testNear(t, col2oklch(grad.At(0.5))[2], 250, 1)
+	testNear(t, col2oklch(grad.At(0))[1], 0, 1e-3)
+	test(t, grad.At(math.NaN()), Color{A: 1})
+	test(t, grad.At(-1), grad.At(0))
+
+	grad = SequentialHue(30, GenerateOptions{Dark: 0.2, Light: 0.8, Chroma: 0.3})
+	testNear(t, oklabL(grad.At(0)), 0.8, 1e-3)
+	testNear(t, oklabL(grad.At(1)), 0.2, 1e-3)
+
+	start, end := Rgb8(0xff, 0xf7, 0xec, 255), Rgb8(0x08, 0x30, 0x6b, 255)
+	grad = Sequential(start, end)
+	test(t, grad.At(0).HexString(), "#fff7ec")
+	test(t, grad.At(1).HexString(), "#08306b")
+	l0, l1 := oklabL(start), oklabL(end)
+	for i, col := range grad.Colors(11) {
+		testNear(t, oklabL(col), l0+(l1-l0)*float64(i)/10, 1e-3)
+	}
+
+	// Endpoints on the edge of the gamut
+	white := Rgb(1, 1, 1, 1)
+	for _, s := range []string{"#0000ff", "#00ff00", "#ff0000", "#00ffff", "#ffff00", "#ff00ff"} {
+		col, _ := csscolorparser.Parse(s)
+		grad = Sequential(white, col)
+		test(t, grad.At(0).HexString(), "#ffffff")
+		test(t, grad.At(1).HexString(), s)
+	}
+	grad = Sequential(Rgb(0, 1, 1, 1), Rgb(1, 1, 0, 1))
+	test(t, grad.At(0).HexString(), "#00ffff")
+	test(t, grad.At(1).HexString(), "#ffff00")
+}
+
+func Test_Diverging(t *testing.T) {
+	grad := DivergingHue(250, 30, GenerateOptions{})
+	colors := grad.Colors(21)
+	for i := range colors {
+		testNear(t, oklabL(colors[i]), oklabL(colors[20-i]), 1e-3)
+	}
+	testNear(t, oklabL(colors[0]), 0.3, 1e-3)
+	testNear(t, oklabL(colors[10]), 0.97, 1e-3)
+	testNear(t, col2oklch(colors[10])[1], 0, 1e-3)
+	testNear(t, col2oklch(colors[2])[2], 250, 1)
+	testNear(t, col2oklch(colors[18])[2], 30, 1)
+
+	start, end := Rgb8(0x21, 0x66, 0xac, 255), Rgb8(0xb2, 0x18, 0x2b, 255)
+	grad = Diverging(start, end, GenerateOptions{Light: 0.95})
+	colors = grad.Colors(21)
+	l := (oklabL(start) + oklabL(end)) / 2
+	testNear(t, oklabL(colors[0]), l, 1e-3)
+	testNear(t, oklabL(colors[20]), l, 1e-3)
+	testNear(t, oklabL(colors[10]), 0.95, 1e-3)
+	for i := range colors {
+		testNear(t, oklabL(colors[i]), oklabL(colors[20-i]), 1e-3)
+	}
+	testNear(t, col2oklch(colors[0])[2], col2oklch(start)[2], 1)
+	testNear(t, col2oklch(colors[20])[2], col2oklch(end)[2], 1)
+}
+
+func Test_Cyclic(t *testing.T) {
+	grad := Cyclic(0, CyclicOptions{})
+	test(t, grad.At(0).HexString(), grad.At(1).HexString())
+	for _, col := range grad.Colors(37) {
+		testNear(t, oklabL(col), 0.75, 1e-3)
+	}
+	testNear(t, col2oklch(grad.At(0.25))[2], 90, 1)
+
+	grad = Cyclic(120, CyclicOptions{Lightness: 0.5, Chroma: 0.3})
+	for _, col := range grad.Colors(37) {
+		testNear(t, oklabL(col), 0.5, 1e-3)
+	}
+	testNear(t, col2oklch(grad.At(0))[2], 120, 1)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"strconv"
 	"strings"
 )
 
@@ -33,6 +34,8 @@ const (
 	rgb coloringType = iota
 	hsvCcw
 	hsvCw
+	// Both colors have the same hue, only the saturation and value change
+	hsvSameHue
 )
 
 type gimpSegment struct {
@@ -137,6 +140,8 @@ func (ggr gimpGradient) At(t float64) Color {
 		return blendHsvCcw(seg.lcolor, seg.rcolor, f)
 	case hsvCw:
 		return blendHsvCw(seg.lcolor, seg.rcolor, f)
+	case hsvSameHue:
+		return blendHsvSameHue(seg.lcolor, seg.rcolor, f)
 	}
 
 	return ggr.segments[0].lcolor
@@ -213,6 +218,37 @@ func blendHsvCw(c1, c2 Color, t float64) Color {
 	)
 }
 
+func blendHsvSameHue(c1, c2 Color, t float64) Color {
+	hsvA := col2hsv(c1)
+	hsvB := col2hsv(c2)
+
+	// A gray has no hue, take it from the other color
+	hue := hsvA[0]
+	if hsvA[1] < hsvB[1] {
+		hue = hsvB[0]
+	}
+
+	return Hsv(
+		hue,
+		hsvA[1]+t*(hsvB[1]-hsvA[1]),
+		hsvA[2]+t*(hsvB[2]-hsvA[2]),
+		hsvA[3]+t*(hsvB[3]-hsvA[3]),
+	)
+}
+
+// GgrSyntaxError is returned by ParseGgr when the GIMP gradient is invalid.
+type GgrSyntaxError struct {
+	// Line number, starting at 1
+	Line int
+	// The offending token, may be the whole line
+	Token  string
+	Reason string
+}
+
+func (e *GgrSyntaxError) Error() string {
+	return fmt.Sprintf("invalid GIMP gradient: line %d: %s: %q", e.Line, e.Reason, e.Token)
+}
+
 func ParseGgr(r io.Reader, fg, bg Color) (Gradient, string, error) {
 	zgrad := Gradient{
 		Core: zeroGradient{},
@@ -223,42 +259,41 @@ func ParseGgr(r io.Reader, fg, bg Color) (Gradient, string, error) {
 	segments := []gimpSegment{}
 	var nseg int
 	var name string
-	xseg := 0
 	i := 0
 	scanner := bufio.NewScanner(r)
 
 	for scanner.Scan() {
+		line := scanner.Text()
+
 		if i == 0 {
-			if scanner.Text() != "GIMP Gradient" {
-				return zgrad, name, fmt.Errorf("invalid header")
+			if line != "GIMP Gradient" {
+				return zgrad, name, &GgrSyntaxError{1, line, "invalid header"}
 			}
 		} else if i == 1 {
-			if !strings.HasPrefix(scanner.Text(), "Name:") {
-				return zgrad, name, fmt.Errorf("invalid header")
+			if !strings.HasPrefix(line, "Name:") {
+				return zgrad, name, &GgrSyntaxError{2, line, "missing gradient name"}
 			}
 
-			name = strings.TrimSpace(scanner.Text()[5:])
+			name = strings.TrimSpace(line[5:])
 		} else if i == 2 {
-			t, ok := parseFloat(scanner.Text())
+			t, ok := parseFloat(line)
 
-			if ok {
-				nseg = int(t)
-			} else {
-				return zgrad, name, fmt.Errorf("invalid header")
+			if !ok || t < 1 || t != math.Trunc(t) {
+				return zgrad, name, &GgrSyntaxError{3, line, "invalid number of segments"}
 			}
+			nseg = int(t)
 		} else {
 			if i >= nseg+3 {
 				break
 			}
 
-			seg, ok := parseSegment(scanner.Text(), fg, bg)
+			seg, err := parseSegment(line, fg, bg)
 
-			if ok {
-				segments = append(segments, seg)
-				xseg++
-			} else {
-				return zgrad, name, fmt.Errorf("invalid segment")
+			if err != nil {
+				err.Line = i + 1
+				return zgrad, name, err
 			}
+			segments = append(segments, seg)
 		}
 		i++
 	}
@@ -267,12 +302,12 @@ func ParseGgr(r io.Reader, fg, bg Color) (Gradient, string, error) {
 		return zgrad, name, err
 	}
 
-	if len(segments) == 0 {
-		return zgrad, name, fmt.Errorf("segments %v", i)
+	if i < 3 {
+		return zgrad, name, &GgrSyntaxError{i + 1, "", "unexpected end of file"}
 	}
 
-	if xseg < nseg {
-		return zgrad, name, fmt.Errorf("wrong segments count, %v, %v", nseg, xseg)
+	if len(segments) < nseg {
+		return zgrad, name, &GgrSyntaxError{i + 1, "", fmt.Sprintf("expected %v segments, found %v", nseg, len(segments))}
 	}
 
 	gradbase := gimpGradient{
@@ -281,19 +316,27 @@ func ParseGgr(r io.Reader, fg, bg Color) (Gradient, string, error) {
 		max:      1,
 	}
 
-	return Gradient{
+	grad := Gradient{
 		Core: gradbase,
 		Min:  0,
 		Max:  1,
-	}, name, nil
+	}
+
+	// The segments with the foreground and background colors resolved
+	var sb strings.Builder
+	WriteGgr(&sb, name, grad, GgrOptions{})
+	grad.spec = &GradientSpec{Ggr: sb.String()}
+
+	return grad, name, nil
 }
 
-func parseSegment(s string, fg, bg Color) (gimpSegment, bool) {
+// Parse a segment line, the returned error has no line number
+func parseSegment(s string, fg, bg Color) (gimpSegment, *GgrSyntaxError) {
 	params := strings.Fields(s)
 	plen := len(params)
 
 	if plen != 13 && plen != 15 {
-		return gimpSegment{}, false
+		return gimpSegment{}, &GgrSyntaxError{0, s, fmt.Sprintf("expected 13 or 15 values, found %v", plen)}
 	}
 
 	d := make([]float64, 15)
@@ -306,7 +349,7 @@ func parseSegment(s string, fg, bg Color) (gimpSegment, bool) {
 			continue
 		}
 
-		return gimpSegment{}, false
+		return gimpSegment{}, &GgrSyntaxError{0, x, "invalid number"}
 	}
 
 	if plen == 13 {
@@ -330,7 +373,7 @@ func parseSegment(s string, fg, bg Color) (gimpSegment, bool) {
 	case 5:
 		blending = step
 	default:
-		return gimpSegment{}, false
+		return gimpSegment{}, &GgrSyntaxError{0, params[11], "invalid blending type"}
 	}
 
 	var coloring coloringType
@@ -343,7 +386,7 @@ func parseSegment(s string, fg, bg Color) (gimpSegment, bool) {
 	case 2:
 		coloring = hsvCw
 	default:
-		return gimpSegment{}, false
+		return gimpSegment{}, &GgrSyntaxError{0, params[12], "invalid coloring type"}
 	}
 
 	var lcolor Color
@@ -360,7 +403,7 @@ func parseSegment(s string, fg, bg Color) (gimpSegment, bool) {
 	case 4:
 		lcolor = Rgb(bg.R, bg.G, bg.B, 0)
 	default:
-		return gimpSegment{}, false
+		return gimpSegment{}, &GgrSyntaxError{0, params[13], "invalid left color type"}
 	}
 
 	var rcolor Color
@@ -377,7 +420,7 @@ func parseSegment(s string, fg, bg Color) (gimpSegment, bool) {
 	case 4:
 		rcolor = Rgb(bg.R, bg.G, bg.B, 0)
 	default:
-		return gimpSegment{}, false
+		return gimpSegment{}, &GgrSyntaxError{0, params[14], "invalid right color type"}
 	}
 
 	return gimpSegment{
@@ -388,5 +431,176 @@ func parseSegment(s string, fg, bg Color) (gimpSegment, bool) {
 		rpos:     d[2],
 		blending: blending,
 		coloring: coloring,
-	}, true
+	}, nil
+}
+
+type GgrOptions struct {
+	// Number of uniform segments used to approximate a gradient which can't
+	// be written exactly, before adaptive subdivision. Default is 16.
+	Segments int
+	// Maximum difference of any color channel between the gradient and the
+	// approximation before a segment is subdivided. Default is 1/255.
+	Tolerance float64
+}
+
+// Maximum number of times a segment is split in half when approximating
+const ggrMaxDepth = 8
+
+// WriteGgr writes g as a GIMP gradient. Gradients from ParseGgr, and linear or
+// sharp gradients blended in RGB, are written exactly. Any other gradient is
+// approximated using linear RGB segments. The domain of g is mapped to [0..1].
+func WriteGgr(w io.Writer, name string, g Gradient, opts GgrOptions) error {
+	var segments []gimpSegment
+
+	if core, ok := g.Core.(gimpGradient); ok {
+		segments = core.segments
+		if g.Min != 0 || g.Max != 1 {
+			segments = normalizeSegments(segments, g.Min, g.Max)
+		}
+	} else {
+		segments = linearRgbSegments(g, opts)
+	}
+
+	name = strings.Join(strings.Fields(name), " ")
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "GIMP Gradient\nName: %s\n%d\n", name, len(segments))
+
+	for _, seg := range segments {
+		vals := []float64{
+			seg.lpos, seg.mpos, seg.rpos,
+			seg.lcolor.R, seg.lcolor.G, seg.lcolor.B, seg.lcolor.A,
+			seg.rcolor.R, seg.rcolor.G, seg.rcolor.B, seg.rcolor.A,
+		}
+		for _, v := range vals {
+			bw.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+			bw.WriteByte(' ')
+		}
+		fmt.Fprintf(bw, "%d %d 0 0\n", seg.blending, seg.coloring)
+	}
+
+	return bw.Flush()
+}
+
+// Linear RGB segments in [0..1] for any gradient, exact for linear and sharp
+// gradients blended in RGB, approximated for the others.
+func linearRgbSegments(g Gradient, opts GgrOptions) []gimpSegment {
+	if opts.Segments < 1 {
+		opts.Segments = 16
+	}
+	if opts.Tolerance <= 0 {
+		opts.Tolerance = 1.0 / 255
+	}
+
+	var segments []gimpSegment
+
+	switch core := g.Core.(type) {
+	case linearGradient:
+		if core.mode == BlendRgb {
+			segments = linearSegments(core.colors, core.positions)
+		}
+	case sharpGradient:
+		colors := make([][4]float64, len(core.colors))
+		for i, c := range core.colors {
+			colors[i] = [4]float64{c.R, c.G, c.B, c.A}
+		}
+		segments = linearSegments(colors, core.positions)
+	}
+
+	if segments == nil {
+		return approximateSegments(g, opts)
+	}
+	if g.Min != 0 || g.Max != 1 {
+		segments = normalizeSegments(segments, g.Min, g.Max)
+	}
+	return segments
+}
+
+// Linear RGB segments between each pair of stops, skipping empty segments
+func linearSegments(colors [][4]float64, positions []float64) []gimpSegment {
+	segments := []gimpSegment{}
+	for i := 0; i < len(positions)-1; i++ {
+		lpos := positions[i]
+		rpos := positions[i+1]
+		if rpos-lpos < epsilon {
+			continue
+		}
+		a := colors[i]
+		b := colors[i+1]
+		segments = append(segments, gimpSegment{
+			lcolor:   Color{R: a[0], G: a[1], B: a[2], A: a[3]},
+			rcolor:   Color{R: b[0], G: b[1], B: b[2], A: b[3]},
+			lpos:     lpos,
+			mpos:     (lpos + rpos) / 2,
+			rpos:     rpos,
+			blending: linear,
+			coloring: rgb,
+		})
+	}
+	return segments
+}
+
+// Map the segment positions from [dmin..dmax] to [0..1]
+func normalizeSegments(segments []gimpSegment, dmin, dmax float64) []gimpSegment {
+	res := make([]gimpSegment, len(segments))
+	for i, seg := range segments {
+		seg.lpos = norm(seg.lpos, dmin, dmax)
+		seg.mpos = norm(seg.mpos, dmin, dmax)
+		seg.rpos = norm(seg.rpos, dmin, dmax)
+		res[i] = seg
+	}
+	res[0].lpos = 0
+	res[len(res)-1].rpos = 1
+	return res
+}
+
+func approximateSegments(g Gradient, opts GgrOptions) []gimpSegment {
+	at := func(t float64) Color {
+		return g.At(g.Min + t*(g.Max-g.Min)).Clamp()
+	}
+
+	segments := []gimpSegment{}
+
+	var subdivide func(l, r float64, lc, rc Color, depth int)
+	subdivide = func(l, r float64, lc, rc Color, depth int) {
+		m := (l + r) / 2
+		mc := at(m)
+		if depth < ggrMaxDepth {
+			for _, f := range []float64{0.25, 0.5, 0.75} {
+				var c Color
+				if f == 0.5 {
+					c = mc
+				} else {
+					c = at(l + f*(r-l))
+				}
+				if colorDistance(c, blendRgb(lc, rc, f)) > opts.Tolerance {
+					subdivide(l, m, lc, mc, depth+1)
+					subdivide(m, r, mc, rc, depth+1)
+					return
+				}
+			}
+		}
+		segments = append(segments, gimpSegment{
+			lcolor:   lc,
+			rcolor:   rc,
+			lpos:     l,
+			mpos:     m,
+			rpos:     r,
+			blending: linear,
+			coloring: rgb,
+		})
+	}
+
+	pos := linspace(0, 1, uint(opts.Segments+1))
+	for i := 0; i < opts.Segments; i++ {
+		subdivide(pos[i], pos[i+1], at(pos[i]), at(pos[i+1]), 0)
+	}
+	return segments
+}
+
+// Largest difference of any channel
+func colorDistance(a, b Color) float64 {
+	return math.Max(
+		math.Max(math.Abs(a.R-b.R), math.Abs(a.G-b.G)),
+		math.Max(math.Abs(a.B-b.B), math.Abs(a.A-b.A)),
+	)
 }
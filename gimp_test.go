@@ -1,6 +1,7 @@
 package colorgrad
 
 import (
+	"errors"
 	"math"
 	"strings"
 	"testing"
@@ -74,4 +75,86 @@ func Test_GIMPGradient(t *testing.T) {
 		_, _, err := ParseGgr(strings.NewReader(s), black, black)
 		testTrue(t, err != nil)
 	}
+
+	// Error position
+
+	errData := []struct {
+		ggr    string
+		line   int
+		token  string
+		reason string
+	}{
+		{"", 1, "", "unexpected end of file"},
+		{"GIMP Palette\nName: Gold", 1, "GIMP Palette", "invalid header"},
+		{"GIMP Gradient\nxx", 2, "xx", "missing gradient name"},
+		{"GIMP Gradient\nName: Gradient\nx", 3, "x", "invalid number of segments"},
+		{"GIMP Gradient\nName: Gradient\n1\n0 0 0", 4, "0 0 0", "expected 13 or 15 values, found 3"},
+		{"GIMP Gradient\nName: Gradient\n1\n0 0.5 1 0 0 0 1 1 1 1 1 0 z", 4, "z", "invalid number"},
+		{"GIMP Gradient\nName: Gradient\n1\n0 0.5 1 0 0 0 1 1 1 1 1 7 0", 4, "7", "invalid blending type"},
+		{"GIMP Gradient\nName: Gradient\n1\n0 0.5 1 0 0 0 1 1 1 1 1 0 0 0 9", 4, "9", "invalid right color type"},
+		{"GIMP Gradient\nName: Gradient\n2\n0 0.5 1 0 0 0 1 1 1 1 1 0 0", 5, "", "expected 2 segments, found 1"},
+	}
+	for _, d := range errData {
+		_, _, err := ParseGgr(strings.NewReader(d.ggr), black, black)
+		var ggrErr *GgrSyntaxError
+		testTrue(t, errors.As(err, &ggrErr))
+		test(t, ggrErr.Line, d.line)
+		test(t, ggrErr.Token, d.token)
+		test(t, ggrErr.Reason, d.reason)
+	}
+}
+
+func Test_WriteGgr(t *testing.T) {
+	black := Rgb(0, 0, 0, 1)
+
+	// Round trip
+	ggr := "GIMP Gradient\nName: My Gradient\n2\n" +
+		"0 0.3 0.5 1 0 0 1 0 0 1 0.5 1 1 0 0\n" +
+		"0.5 0.75 1 0 0 1 0.5 1 1 0 1 5 2 0 0\n"
+	grad, name, err := ParseGgr(strings.NewReader(ggr), black, black)
+	test(t, err, nil)
+
+	var sb strings.Builder
+	err = WriteGgr(&sb, name, grad, GgrOptions{})
+	test(t, err, nil)
+	test(t, sb.String(), ggr)
+
+	// Linear RGB gradient is written exactly
+	grad, _ = NewGradient().
+		HtmlColors("#f00", "#0f0", "#00f").
+		Domain(-10, 0, 10).
+		Build()
+
+	sb.Reset()
+	err = WriteGgr(&sb, "RGB\n", grad, GgrOptions{})
+	test(t, err, nil)
+	test(t, sb.String(), "GIMP Gradient\nName: RGB\n2\n"+
+		"0 0.25 0.5 1 0 0 1 0 1 0 1 0 0 0 0\n"+
+		"0.5 0.75 1 0 1 0 1 0 0 1 1 0 0 0 0\n")
+
+	grad2, _, err := ParseGgr(strings.NewReader(sb.String()), black, black)
+	test(t, err, nil)
+	testSlice(t, colors2hex(grad2.Colors(9)), colors2hex(grad.Colors(9)))
+
+	// Sharp gradient is written exactly
+	grad = grad.Sharp(3, 0)
+	sb.Reset()
+	err = WriteGgr(&sb, "Sharp", grad, GgrOptions{})
+	test(t, err, nil)
+	grad2, _, err = ParseGgr(strings.NewReader(sb.String()), black, black)
+	test(t, err, nil)
+	testSlice(t, colors2hex(grad2.Colors(19)), colors2hex(grad.Colors(19)))
+
+	// Other gradients are approximated
+	for _, grad := range []Gradient{Rainbow(), Viridis(), Sinebow()} {
+		sb.Reset()
+		err = WriteGgr(&sb, "Preset", grad, GgrOptions{Segments: 8})
+		test(t, err, nil)
+		grad2, _, err = ParseGgr(strings.NewReader(sb.String()), black, black)
+		test(t, err, nil)
+		for i := 0; i <= 100; i++ {
+			x := float64(i) / 100
+			testTrue(t, colorDistance(grad.At(x), grad2.At(x)) < 2.0/255)
+		}
+	}
 }
@@ -14,6 +14,11 @@ const (
 	BlendLinearRgb
 	BlendLab
 	BlendOklab
+	BlendOklch
+	BlendLch
+	BlendHsl
+	BlendHsv
+	BlendHwb
 )
 
 func (b BlendMode) String() string {
@@ -26,6 +31,41 @@ func (b BlendMode) String() string {
 		return "BlendLab"
 	case BlendOklab:
 		return "BlendOklab"
+	case BlendOklch:
+		return "BlendOklch"
+	case BlendLch:
+		return "BlendLch"
+	case BlendHsl:
+		return "BlendHsl"
+	case BlendHsv:
+		return "BlendHsv"
+	case BlendHwb:
+		return "BlendHwb"
+	}
+	return ""
+}
+
+// Hue interpolation method used by the polar blend modes (BlendOklch,
+// BlendLch, BlendHsl, BlendHsv, BlendHwb), as in CSS Color Module Level 4.
+type HueInterpolation int
+
+const (
+	HueShorter HueInterpolation = iota
+	HueLonger
+	HueIncreasing
+	HueDecreasing
+)
+
+func (h HueInterpolation) String() string {
+	switch h {
+	case HueShorter:
+		return "HueShorter"
+	case HueLonger:
+		return "HueLonger"
+	case HueIncreasing:
+		return "HueIncreasing"
+	case HueDecreasing:
+		return "HueDecreasing"
 	}
 	return ""
 }
@@ -95,32 +135,57 @@ type Gradient struct {
 	Core GradientCore
 	Min  float64
 	Max  float64
+	// Color for NaN, nil to use the color of the core
+	NaN *Color
+	// Color for positions below Min, nil to use the first color
+	Under *Color
+	// Color for positions above Max, nil to use the last color
+	Over *Color
+	// How the gradient was created, see Spec
+	spec *GradientSpec
 }
 
 // Get color at certain position
 func (g Gradient) At(t float64) Color {
+	if math.IsNaN(t) {
+		if g.NaN != nil {
+			return *g.NaN
+		}
+	} else if t < g.Min {
+		if g.Under != nil {
+			return *g.Under
+		}
+	} else if t > g.Max {
+		if g.Over != nil {
+			return *g.Over
+		}
+	}
 	return g.Core.At(t)
 }
 
 // Get color at certain position
 func (g Gradient) RepeatAt(t float64) Color {
+	if math.IsNaN(t) && g.NaN != nil {
+		return *g.NaN
+	}
 	t = norm(t, g.Min, g.Max)
 	return g.Core.At(g.Min + modulo(t, 1)*(g.Max-g.Min))
 }
 
 // Get color at certain position
 func (g Gradient) ReflectAt(t float64) Color {
+	if math.IsNaN(t) && g.NaN != nil {
+		return *g.NaN
+	}
 	t = norm(t, g.Min, g.Max)
 	return g.Core.At(g.Min + math.Abs(modulo(1+t, 2)-1)*(g.Max-g.Min))
 }
 
 // Get n colors evenly spaced across gradient
 func (g Gradient) Colors(count uint) []Color {
-	d := g.Max - g.Min
-	l := float64(count) - 1
 	colors := make([]Color, count)
-	for i := range colors {
-		colors[i] = g.Core.At(g.Min + (float64(i)*d)/l).Clamp()
+	for i, t := range linspace(g.Min, g.Max, count) {
+		colors[i] = g.At(t).Clamp()
 	}
 	return colors
 }
@@ -139,7 +204,9 @@ func (g Gradient) Sharp(segment uint, smoothness float64) Gradient {
 		colors = append(colors, g.At(g.Min))
 		colors = append(colors, g.At(g.Min))
 	}
-	return newSharpGradient(colors, g.Min, g.Max, smoothness)
+	grad := newSharpGradient(colors, g.Min, g.Max, smoothness)
+	grad.NaN, grad.Under, grad.Over = g.NaN, g.Under, g.Over
+	return grad
 }
 
 type zeroGradient struct {
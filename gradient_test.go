@@ -3,6 +3,7 @@ package colorgrad
 import (
 	"fmt"
 	"image/color"
+	"math"
 	"testing"
 )
 
@@ -26,6 +27,11 @@ func Test_Basic(t *testing.T) {
 	test(t, BlendRgb.String(), "BlendRgb")
 	test(t, fmt.Sprintf("%s", BlendLinearRgb), "BlendLinearRgb")
 	test(t, fmt.Sprintf("%v", BlendOklab), "BlendOklab")
+	test(t, BlendOklch.String(), "BlendOklch")
+	test(t, fmt.Sprintf("%s", BlendHwb), "BlendHwb")
+
+	test(t, HueShorter.String(), "HueShorter")
+	test(t, fmt.Sprintf("%v", HueDecreasing), "HueDecreasing")
 
 	test(t, InterpolationLinear.String(), "InterpolationLinear")
 	test(t, fmt.Sprintf("%s", InterpolationCatmullRom), "InterpolationCatmullRom")
@@ -58,6 +64,16 @@ func Test_GetColors(t *testing.T) {
 		"#008080",
 		"#0000ff",
 	})
+
+	// The last color is at max, not past it
+	grad, _ = NewGradient().
+		HtmlColors("#f00", "#00f").
+		Domain(-5.5, -3.857142857142857).
+		OverColor(Rgb(0, 1, 0, 1)).
+		Build()
+	test(t, grad.Colors(4)[3].HexString(), "#0000ff")
+	test(t, grad.Sharp(4, 0).Colors(4)[3].HexString(), "#0000ff")
+	testSlice(t, linspace(-5.5, -3.857142857142857, 4)[3:], []float64{-3.857142857142857})
 }
 
 func Test_SpreadRepeat(t *testing.T) {
@@ -121,3 +137,48 @@ func Test_SpreadReflect(t *testing.T) {
 	test(t, grad.ReflectAt(2.5).HexString(), "#808080")
 	test(t, grad.ReflectAt(2.9).HexString(), "#e5e5e5")
 }
+
+func Test_OutOfRangeColors(t *testing.T) {
+	nan := Rgb(1, 0, 1, 1)
+	under := Rgb(0, 0, 1, 1)
+	over := Rgb(1, 0, 0, 1)
+
+	grad, err := NewGradient().
+		HtmlColors("black", "white").
+		Domain(-1, 1).
+		NaNColor(nan).
+		UnderColor(under).
+		OverColor(over).
+		Build()
+	test(t, err, nil)
+	test(t, grad.At(math.NaN()).HexString(), "#ff00ff")
+	test(t, grad.At(-1.01).HexString(), "#0000ff")
+	test(t, grad.At(-1).HexString(), "#000000")
+	test(t, grad.At(1).HexString(), "#ffffff")
+	test(t, grad.At(1.01).HexString(), "#ff0000")
+
+	// Repeat and reflect stay in the domain
+	test(t, grad.RepeatAt(math.NaN()).HexString(), "#ff00ff")
+	test(t, grad.RepeatAt(1.5).HexString(), "#404040")
+	test(t, grad.ReflectAt(math.NaN()).HexString(), "#ff00ff")
+	test(t, grad.ReflectAt(-1.5).HexString(), "#404040")
+
+	testSlice(t, colors2hex(grad.Colors(3)), []string{"#000000", "#808080", "#ffffff"})
+	test(t, grad.Colors(1)[0].HexString(), "#000000")
+
+	sharp := grad.Sharp(2, 0)
+	test(t, sharp.At(math.NaN()).HexString(), "#ff00ff")
+	test(t, sharp.At(-2).HexString(), "#0000ff")
+	test(t, sharp.At(2).HexString(), "#ff0000")
+
+	// Set on the gradient
+	grad, _ = NewGradient().Build()
+	test(t, grad.At(math.NaN()).HexString(), "#000000")
+	grad.NaN = &nan
+	test(t, grad.At(math.NaN()).HexString(), "#ff00ff")
+	test(t, grad.At(2).HexString(), "#ffffff")
+
+	// Reset
+	grad, _ = NewGradient().NaNColor(nan).Reset().Build()
+	test(t, grad.NaN, (*Color)(nil))
+}
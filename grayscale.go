@@ -0,0 +1,77 @@
+package colorgrad
+
+type LightnessScale int
+
+const (
+	// CIE L*, in [0..100]
+	LightnessLab LightnessScale = iota
+	// Oklab L, in [0..1]
+	LightnessOklab
+)
+
+func (s LightnessScale) String() string {
+	switch s {
+	case LightnessLab:
+		return "LightnessLab"
+	case LightnessOklab:
+		return "LightnessOklab"
+	}
+	return ""
+}
+
+// Lightness of a color on the scale
+func (s LightnessScale) Lightness(col Color) float64 {
+	if s == LightnessOklab {
+		return col2oklab(col)[0]
+	}
+	return col2lab(col)[0]
+}
+
+// Gray color with the lightness of col on the scale. Alpha is kept.
+func (s LightnessScale) Gray(col Color) Color {
+	var y float64
+	if s == LightnessOklab {
+		l := col2oklab(col)[0]
+		y = l * l * l
+	} else {
+		l := col2lab(col)[0]
+		if l > 8 {
+			y = (l + 16) / 116
+			y = y * y * y
+		} else {
+			y = l * 27 / 24389
+		}
+	}
+	y = clamp01(y)
+	return LinearRgb(y, y, y, col.A)
+}
+
+type grayscaleGradient struct {
+	core  GradientCore
+	scale LightnessScale
+}
+
+func (gg grayscaleGradient) At(t float64) Color {
+	return gg.scale.Gray(gg.core.At(t))
+}
+
+// Return the gradient in shades of gray with the same lightness, e.g. to
+// preview how it prints in black and white. The NaN, under and over colors
+// are converted too.
+func (g Gradient) Grayscale(scale LightnessScale) Gradient {
+	gray := func(col *Color) *Color {
+		if col == nil {
+			return nil
+		}
+		res := scale.Gray(*col)
+		return &res
+	}
+	return Gradient{
+		Core:  grayscaleGradient{g.Core, scale},
+		Min:   g.Min,
+		Max:   g.Max,
+		NaN:   gray(g.NaN),
+		Under: gray(g.Under),
+		Over:  gray(g.Over),
+	}
+}
@@ -0,0 +1,48 @@
+package colorgrad
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_Grayscale(t *testing.T) {
+	colors := []Color{
+		Rgb(1, 0, 0, 1), Rgb(0, 1, 0, 1), Rgb(0, 0, 1, 1), Rgb(1, 1, 0, 1),
+		Rgb8(0x12, 0x34, 0x56, 255), Rgb(0, 0, 0, 1), Rgb(1, 1, 1, 1),
+	}
+	for _, scale := range []LightnessScale{LightnessLab, LightnessOklab} {
+		tolerance := 1e-2
+		if scale == LightnessOklab {
+			tolerance = 1e-4
+		}
+		for _, col := range colors {
+			gray := scale.Gray(col)
+			r, g, b, _ := gray.RGBA255()
+			testTrue(t, r == g && g == b)
+			// Within the rounding of the channels
+			testTrue(t, math.Abs(scale.Lightness(gray)-scale.Lightness(col)) < tolerance)
+		}
+	}
+
+	test(t, LightnessLab.Gray(Rgb8(128, 128, 128, 128)).HexString(), "#80808080")
+	test(t, LightnessOklab.Gray(Rgb8(128, 128, 128, 255)).HexString(), "#808080")
+	test(t, LightnessLab.Gray(Rgb(1, 0, 0, 1)).HexString(), "#7f7f7f")
+
+	testTrue(t, math.Abs(LightnessLab.Lightness(Rgb(1, 1, 1, 1))-100) < 1e-3)
+	testTrue(t, math.Abs(LightnessOklab.Lightness(Rgb(1, 1, 1, 1))-1) < 1e-3)
+	test(t, LightnessOklab.String(), "LightnessOklab")
+
+	grad, _ := NewGradient().
+		HtmlColors("#f00", "#00f").
+		Domain(-1, 1).
+		Build()
+	grad.Over = &Color{G: 1, A: 1}
+
+	gray := grad.Grayscale(LightnessLab)
+	test(t, gray.Min, -1.0)
+	test(t, gray.Max, 1.0)
+	test(t, gray.At(-1).HexString(), "#7f7f7f")
+	test(t, gray.At(0), LightnessLab.Gray(grad.At(0)))
+	test(t, gray.At(2), LightnessLab.Gray(*grad.Over))
+	testTrue(t, gray.NaN == nil && gray.Under == nil)
+}
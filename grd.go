@@ -0,0 +1,700 @@
+package colorgrad
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"unicode/utf16"
+)
+
+// References:
+// https://www.adobe.com/devnet-apps/photoshop/fileformatashtml/ (Descriptor structure)
+// http://soliton.vm.bytemark.co.uk/pub/jjg/en/code/cptutils/ (grd3, grd5)
+
+// ErrNoiseGradient is reported for the noise gradients of a Photoshop
+// gradient file, which are not supported.
+var ErrNoiseGradient = errors.New("noise gradients are not supported")
+
+// A gradient in a Photoshop gradient file
+type GrdEntry struct {
+	Name     string
+	Gradient Gradient
+	// Non-nil if the gradient can't be converted, e.g. ErrNoiseGradient
+	Err error
+}
+
+// Photoshop stores locations in [0..4096] and midpoints in [0..100]
+const grdMaxLocation = 4096
+
+type grdColorStop struct {
+	color    Color
+	location float64
+	midpoint float64
+}
+
+type grdOpacityStop struct {
+	opacity  float64
+	location float64
+	midpoint float64
+}
+
+// ParseGrd reads a Photoshop gradient file (.grd), version 3 or 5. The fg and
+// bg colors are used for the "Foreground" and "Background" color stops.
+func ParseGrd(r io.Reader, fg, bg Color) ([]GrdEntry, error) {
+	gr := &grdReader{r: bufio.NewReader(r)}
+
+	if string(gr.bytes(4)) != "8BGR" {
+		if gr.err != nil {
+			return nil, gr.err
+		}
+		return nil, fmt.Errorf("invalid GRD header")
+	}
+
+	switch version := gr.u16(); version {
+	case 3:
+		return parseGrd3(gr, fg, bg)
+	case 5:
+		return parseGrd5(gr, fg, bg)
+	default:
+		if gr.err != nil {
+			return nil, gr.err
+		}
+		return nil, fmt.Errorf("unsupported GRD version %v", version)
+	}
+}
+
+func parseGrd3(gr *grdReader, fg, bg Color) ([]GrdEntry, error) {
+	count := int(gr.u16())
+	entries := []GrdEntry{}
+
+	for i := 0; i < count && gr.err == nil; i++ {
+		name := string(gr.bytes(int(gr.u8())))
+
+		colors := make([]grdColorStop, gr.u16())
+		for j := range colors {
+			loc := float64(gr.u32())
+			mid := float64(gr.u32())
+			model := gr.u16()
+			var v [4]float64
+			for k := range v {
+				v[k] = float64(gr.u16())
+			}
+			col, err := grd3Color(model, v)
+			switch gr.u16() {
+			case 0:
+			case 1:
+				col, err = fg, nil
+			case 2:
+				col, err = bg, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			colors[j] = grdColorStop{col, loc, mid}
+		}
+
+		opacities := make([]grdOpacityStop, gr.u16())
+		for j := range opacities {
+			loc := float64(gr.u32())
+			mid := float64(gr.u32())
+			opacities[j] = grdOpacityStop{float64(gr.u16()) / 255, loc, mid}
+		}
+
+		// Unused
+		gr.bytes(6)
+
+		grad, err := grdGradient(colors, opacities)
+		entries = append(entries, GrdEntry{name, grad, err})
+	}
+
+	if gr.err != nil {
+		return nil, gr.err
+	}
+	return entries, nil
+}
+
+func grd3Color(model uint16, v [4]float64) (Color, error) {
+	switch model {
+	case 0:
+		return Rgb(v[0]/65535, v[1]/65535, v[2]/65535, 1), nil
+	case 1:
+		return Hsv(v[0]/65535*360, v[1]/65535, v[2]/65535, 1), nil
+	case 2:
+		// 0 is 100% ink
+		return cmykToColor(1-v[0]/65535, 1-v[1]/65535, 1-v[2]/65535, 1-v[3]/65535), nil
+	case 7:
+		return Lab(v[0]/100, float64(int16(v[1]))/100, float64(int16(v[2]))/100, 1).Clamp(), nil
+	case 8:
+		g := 1 - v[0]/10000
+		return Rgb(g, g, g, 1), nil
+	}
+	return Color{}, fmt.Errorf("unsupported GRD color model %v", model)
+}
+
+func cmykToColor(c, m, y, k float64) Color {
+	return Rgb((1-c)*(1-k), (1-m)*(1-k), (1-y)*(1-k), 1)
+}
+
+func parseGrd5(gr *grdReader, fg, bg Color) ([]GrdEntry, error) {
+	if gr.u32() != 16 {
+		if gr.err != nil {
+			return nil, gr.err
+		}
+		return nil, fmt.Errorf("unsupported GRD descriptor version")
+	}
+
+	root := gr.descriptor()
+	if gr.err != nil {
+		return nil, gr.err
+	}
+
+	list, ok := root.items["GrdL"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid GRD file: missing gradient list")
+	}
+
+	entries := []GrdEntry{}
+
+	for _, item := range list {
+		obj, ok := item.(grdDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("invalid GRD file: invalid gradient")
+		}
+		if g, ok := obj.items["Grad"].(grdDescriptor); ok {
+			obj = g
+		}
+		name, _ := obj.items["Nm  "].(string)
+		entry := GrdEntry{
+			Name: trimNull(name),
+			Gradient: Gradient{
+				Core: zeroGradient{},
+				Min:  0,
+				Max:  1,
+			},
+		}
+
+		form, _ := obj.items["GrdF"].(grdEnum)
+		if form.value == "ClNs" {
+			entry.Err = ErrNoiseGradient
+			entries = append(entries, entry)
+			continue
+		}
+
+		colors := []grdColorStop{}
+		clrs, _ := obj.items["Clrs"].([]any)
+		for _, c := range clrs {
+			stop, ok := c.(grdDescriptor)
+			if !ok {
+				return nil, fmt.Errorf("invalid GRD file: invalid color stop")
+			}
+			var col Color
+			var err error
+			typ, _ := stop.items["Type"].(grdEnum)
+			switch typ.value {
+			case "FrgC":
+				col = fg
+			case "BckC":
+				col = bg
+			default:
+				clr, _ := stop.items["Clr "].(grdDescriptor)
+				col, err = grd5Color(clr)
+			}
+			if err != nil {
+				entry.Err = err
+				break
+			}
+			colors = append(colors, grdColorStop{col, grdNumber(stop.items["Lctn"]), grdNumber(stop.items["Mdpn"])})
+		}
+
+		opacities := []grdOpacityStop{}
+		trns, _ := obj.items["Trns"].([]any)
+		for _, t := range trns {
+			stop, ok := t.(grdDescriptor)
+			if !ok {
+				return nil, fmt.Errorf("invalid GRD file: invalid opacity stop")
+			}
+			opacities = append(opacities, grdOpacityStop{
+				grdNumber(stop.items["Opct"]) / 100,
+				grdNumber(stop.items["Lctn"]),
+				grdNumber(stop.items["Mdpn"]),
+			})
+		}
+
+		if entry.Err == nil {
+			entry.Gradient, entry.Err = grdGradient(colors, opacities)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func grd5Color(clr grdDescriptor) (Color, error) {
+	v := func(key string) float64 {
+		return grdNumber(clr.items[key])
+	}
+	switch clr.class {
+	case "RGBC":
+		return Rgb(v("Rd  ")/255, v("Grn ")/255, v("Bl  ")/255, 1), nil
+	case "HSBC":
+		return Hsv(v("H   "), v("Strt")/100, v("Brgh")/100, 1), nil
+	case "CMYC":
+		return cmykToColor(v("Cyn ")/100, v("Mgnt")/100, v("Ylw ")/100, v("Blck")/100), nil
+	case "LbCl":
+		return Lab(v("Lmnc"), v("A   "), v("B   "), 1).Clamp(), nil
+	case "Grsc":
+		g := 1 - v("Gry ")/100
+		return Rgb(g, g, g, 1), nil
+	}
+	return Color{}, fmt.Errorf("unsupported GRD color %q", clr.class)
+}
+
+// Build a gradient from color and opacity stops. Midpoints other than 50% are
+// approximated by an extra stop at the midpoint, as for CSS transition hints.
+func grdGradient(colors []grdColorStop, opacities []grdOpacityStop) (Gradient, error) {
+	zgrad := Gradient{
+		Core: zeroGradient{},
+		Min:  0,
+		Max:  1,
+	}
+
+	if len(colors) == 0 {
+		return zgrad, fmt.Errorf("invalid GRD gradient: no color stops")
+	}
+
+	positions := []float64{}
+	cc := []Color{}
+	for i, s := range colors {
+		if i > 0 {
+			positions, cc = grdMidpoint(positions, cc, colors[i-1].location, s.location, s.midpoint, cc[len(cc)-1], s.color)
+		}
+		positions = append(positions, s.location/grdMaxLocation)
+		cc = append(cc, s.color)
+	}
+
+	apos := []float64{}
+	ac := []Color{}
+	for i, s := range opacities {
+		col := Rgb(s.opacity, s.opacity, s.opacity, 1)
+		if i > 0 {
+			apos, ac = grdMidpoint(apos, ac, opacities[i-1].location, s.location, s.midpoint, ac[len(ac)-1], col)
+		}
+		apos = append(apos, s.location/grdMaxLocation)
+		ac = append(ac, col)
+	}
+	if len(ac) == 0 {
+		apos = []float64{0, 1}
+		ac = []Color{Rgb(1, 1, 1, 1), Rgb(1, 1, 1, 1)}
+	}
+
+	colorGrad, err := grdStops(cc, positions)
+	if err != nil {
+		return zgrad, err
+	}
+	alphaGrad, err := grdStops(ac, apos)
+	if err != nil {
+		return zgrad, err
+	}
+
+	// Both are piecewise linear, sample them at every stop of either
+	all := append(append([]float64{0}, positions...), apos...)
+	all = append(all, 1)
+	sort.Float64s(all)
+
+	resPos := []float64{}
+	resColors := []Color{}
+	for i, p := range all {
+		if i > 0 && p == all[i-1] {
+			continue
+		}
+		// Keep hard edges
+		for _, side := range []float64{-1, 1} {
+			c := stopColorAt(colorGrad, p, side)
+			a := stopColorAt(alphaGrad, p, side).R
+			c.A = a
+			if len(resColors) > 0 && resPos[len(resPos)-1] == p && resColors[len(resColors)-1] == c {
+				continue
+			}
+			resPos = append(resPos, p)
+			resColors = append(resColors, c)
+		}
+	}
+
+	return NewGradient().
+		Colors(resColors...).
+		Domain(resPos...).
+		Build()
+}
+
+// Insert a stop with the halfway color at the midpoint, if it's not at the
+// center of the segment.
+func grdMidpoint(pos []float64, colors []Color, lloc, rloc, mid float64, a, b Color) ([]float64, []Color) {
+	if math.Abs(mid-50) < epsilon || rloc-lloc < epsilon {
+		return pos, colors
+	}
+	m := (lloc + (rloc-lloc)*clamp01(mid/100)) / grdMaxLocation
+	return append(pos, m), append(colors, blendRgb(a, b, 0.5))
+}
+
+func grdStops(colors []Color, positions []float64) (linearGradient, error) {
+	if len(colors) == 1 {
+		colors = []Color{colors[0], colors[0]}
+		positions = []float64{0, 1}
+	}
+	grad, err := NewGradient().
+		Colors(colors...).
+		Domain(positions...).
+		Build()
+	if err != nil {
+		return linearGradient{}, fmt.Errorf("invalid GRD gradient: %v", err)
+	}
+	return grad.Core.(linearGradient), nil
+}
+
+// Color at a stop position, from the left (side < 0) or the right of it
+func stopColorAt(g linearGradient, t, side float64) Color {
+	if t <= g.min {
+		return g.first
+	}
+	if t >= g.max {
+		return g.last
+	}
+	for i, p := range g.positions {
+		if p != t {
+			continue
+		}
+		if side > 0 {
+			for i+1 < len(g.positions) && g.positions[i+1] == t {
+				i++
+			}
+		}
+		c := g.colors[i]
+		return Color{R: c[0], G: c[1], B: c[2], A: c[3]}
+	}
+	return g.At(t)
+}
+
+func trimNull(s string) string {
+	for len(s) > 0 && s[len(s)-1] == 0 {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// WriteGrd writes the gradients as a Photoshop gradient file (version 5).
+// Linear RGB gradients are written exactly, others are approximated, as in
+// WriteGgr. The domain of each gradient is mapped to [0..1] and the Err field
+// of the entries is ignored.
+func WriteGrd(w io.Writer, entries []GrdEntry) error {
+	gw := &grdWriter{w: bufio.NewWriter(w)}
+	gw.bytes([]byte("8BGR"))
+	gw.u16(5)
+	gw.u32(16)
+
+	gw.descriptorHeader("", "null", 1)
+	gw.key("GrdL")
+	gw.bytes([]byte("VlLs"))
+	gw.u32(uint32(len(entries)))
+
+	for _, e := range entries {
+		segments := linearRgbSegments(e.Gradient, GgrOptions{})
+
+		colors := []grdColorStop{}
+		opacities := []grdOpacityStop{}
+		add := func(pos float64, c Color) {
+			loc := math.Round(clamp01(pos) * grdMaxLocation)
+			c = c.Clamp()
+			n := len(colors)
+			if n == 0 || colors[n-1].location != loc || colors[n-1].color != c {
+				colors = append(colors, grdColorStop{Rgb(c.R, c.G, c.B, 1), loc, 50})
+				opacities = append(opacities, grdOpacityStop{c.A, loc, 50})
+			}
+		}
+		for _, seg := range segments {
+			add(seg.lpos, seg.lcolor)
+			add(seg.rpos, seg.rcolor)
+		}
+
+		gw.bytes([]byte("Objc"))
+		gw.descriptorHeader("", "Grdn", 1)
+		gw.key("Grad")
+		gw.bytes([]byte("Objc"))
+		gw.descriptorHeader("Gradient", "Grdn", 5)
+
+		gw.key("Nm  ")
+		gw.bytes([]byte("TEXT"))
+		gw.unicode(e.Name)
+
+		gw.key("GrdF")
+		gw.enum("GrdF", "CstS")
+
+		gw.key("Intr")
+		gw.bytes([]byte("doub"))
+		gw.f64(grdMaxLocation)
+
+		gw.key("Clrs")
+		gw.bytes([]byte("VlLs"))
+		gw.u32(uint32(len(colors)))
+		for _, s := range colors {
+			gw.bytes([]byte("Objc"))
+			gw.descriptorHeader("", "Clrt", 4)
+			gw.key("Clr ")
+			gw.bytes([]byte("Objc"))
+			gw.descriptorHeader("", "RGBC", 3)
+			for i, k := range []string{"Rd  ", "Grn ", "Bl  "} {
+				gw.key(k)
+				gw.bytes([]byte("doub"))
+				gw.f64([]float64{s.color.R, s.color.G, s.color.B}[i] * 255)
+			}
+			gw.key("Type")
+			gw.enum("Clry", "UsrS")
+			gw.key("Lctn")
+			gw.long(int32(s.location))
+			gw.key("Mdpn")
+			gw.long(int32(s.midpoint))
+		}
+
+		gw.key("Trns")
+		gw.bytes([]byte("VlLs"))
+		gw.u32(uint32(len(opacities)))
+		for _, s := range opacities {
+			gw.bytes([]byte("Objc"))
+			gw.descriptorHeader("", "TrnS", 3)
+			gw.key("Opct")
+			gw.bytes([]byte("UntF"))
+			gw.bytes([]byte("#Prc"))
+			gw.f64(s.opacity * 100)
+			gw.key("Lctn")
+			gw.long(int32(s.location))
+			gw.key("Mdpn")
+			gw.long(int32(s.midpoint))
+		}
+	}
+
+	if gw.err != nil {
+		return gw.err
+	}
+	return gw.w.Flush()
+}
+
+// --- Descriptor reading and writing
+
+type grdDescriptor struct {
+	class string
+	items map[string]any
+}
+
+type grdEnum struct {
+	typ   string
+	value string
+}
+
+type grdUnitFloat struct {
+	unit  string
+	value float64
+}
+
+func grdNumber(v any) float64 {
+	switch x := v.(type) {
+	case float64:
+		return x
+	case int32:
+		return float64(x)
+	case int64:
+		return float64(x)
+	case grdUnitFloat:
+		return x.value
+	}
+	return 0
+}
+
+type grdReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (gr *grdReader) bytes(n int) []byte {
+	if gr.err != nil {
+		return nil
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(gr.r, b); err != nil {
+		gr.err = fmt.Errorf("invalid GRD file: %w", err)
+		return nil
+	}
+	return b
+}
+
+func (gr *grdReader) u8() uint8 {
+	b := gr.bytes(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (gr *grdReader) u16() uint16 {
+	b := gr.bytes(2)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint16(b)
+}
+
+func (gr *grdReader) u32() uint32 {
+	b := gr.bytes(4)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+// Guard against allocating huge buffers for corrupted files
+const grdMaxLength = 1 << 20
+
+func (gr *grdReader) length() int {
+	n := gr.u32()
+	if n > grdMaxLength && gr.err == nil {
+		gr.err = fmt.Errorf("invalid GRD file: length %v too large", n)
+		return 0
+	}
+	return int(n)
+}
+
+func (gr *grdReader) unicode() string {
+	n := gr.length()
+	units := make([]uint16, n)
+	for i := range units {
+		units[i] = gr.u16()
+	}
+	return string(utf16.Decode(units))
+}
+
+// Key or class ID: 4 bytes when the length is 0
+func (gr *grdReader) key() string {
+	n := gr.length()
+	if n == 0 {
+		n = 4
+	}
+	return string(gr.bytes(n))
+}
+
+func (gr *grdReader) descriptor() grdDescriptor {
+	gr.unicode()
+	d := grdDescriptor{
+		class: gr.key(),
+		items: map[string]any{},
+	}
+	n := gr.length()
+	for i := 0; i < n && gr.err == nil; i++ {
+		k := gr.key()
+		d.items[k] = gr.value(string(gr.bytes(4)))
+	}
+	return d
+}
+
+func (gr *grdReader) value(typ string) any {
+	if gr.err != nil {
+		return nil
+	}
+	switch typ {
+	case "Objc", "GlbO":
+		return gr.descriptor()
+	case "VlLs":
+		n := gr.length()
+		list := []any{}
+		for i := 0; i < n && gr.err == nil; i++ {
+			list = append(list, gr.value(string(gr.bytes(4))))
+		}
+		return list
+	case "doub":
+		return math.Float64frombits(uint64(gr.u32())<<32 | uint64(gr.u32()))
+	case "UntF":
+		unit := string(gr.bytes(4))
+		return grdUnitFloat{unit, math.Float64frombits(uint64(gr.u32())<<32 | uint64(gr.u32()))}
+	case "TEXT":
+		return gr.unicode()
+	case "enum":
+		return grdEnum{gr.key(), gr.key()}
+	case "long":
+		return int32(gr.u32())
+	case "comp":
+		return int64(uint64(gr.u32())<<32 | uint64(gr.u32()))
+	case "bool":
+		return gr.u8() != 0
+	case "type", "GlbC":
+		gr.unicode()
+		return gr.key()
+	case "tdta", "alis":
+		return gr.bytes(gr.length())
+	}
+	gr.err = fmt.Errorf("invalid GRD file: unsupported descriptor type %q", typ)
+	return nil
+}
+
+type grdWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (gw *grdWriter) bytes(b []byte) {
+	if gw.err == nil {
+		_, gw.err = gw.w.Write(b)
+	}
+}
+
+func (gw *grdWriter) u16(v uint16) {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, v)
+	gw.bytes(b)
+}
+
+func (gw *grdWriter) u32(v uint32) {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	gw.bytes(b)
+}
+
+func (gw *grdWriter) f64(v float64) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, math.Float64bits(v))
+	gw.bytes(b)
+}
+
+func (gw *grdWriter) long(v int32) {
+	gw.bytes([]byte("long"))
+	gw.u32(uint32(v))
+}
+
+func (gw *grdWriter) unicode(s string) {
+	units := append(utf16.Encode([]rune(s)), 0)
+	gw.u32(uint32(len(units)))
+	for _, u := range units {
+		gw.u16(u)
+	}
+}
+
+func (gw *grdWriter) key(k string) {
+	if len(k) == 4 {
+		gw.u32(0)
+	} else {
+		gw.u32(uint32(len(k)))
+	}
+	gw.bytes([]byte(k))
+}
+
+func (gw *grdWriter) enum(typ, value string) {
+	gw.bytes([]byte("enum"))
+	gw.key(typ)
+	gw.key(value)
+}
+
+func (gw *grdWriter) descriptorHeader(name, class string, count int) {
+	gw.unicode(name)
+	gw.key(class)
+	gw.u32(uint32(count))
+}
@@ -0,0 +1,145 @@
+package colorgrad
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func Test_GrdRoundTrip(t *testing.T) {
+	black := Rgb(0, 0, 0, 1)
+
+	grad1, _ := NewGradient().
+		HtmlColors("#f00", "#00ff0080", "#00f").
+		Domain(0, 0.25, 1).
+		Build()
+
+	grad2, _ := NewGradient().
+		HtmlColors("gold", "seagreen").
+		Build()
+
+	var buf bytes.Buffer
+	err := WriteGrd(&buf, []GrdEntry{
+		{Name: "Transparent RGB", Gradient: grad1},
+		{Name: "Gold ✓", Gradient: grad2.Sharp(3, 0)},
+		{Name: "Viridis", Gradient: Viridis()},
+	})
+	test(t, err, nil)
+
+	entries, err := ParseGrd(&buf, black, black)
+	test(t, err, nil)
+	test(t, len(entries), 3)
+
+	test(t, entries[0].Name, "Transparent RGB")
+	test(t, entries[0].Err, nil)
+	testSlice(t, colors2hex(entries[0].Gradient.Colors(9)), colors2hex(grad1.Colors(9)))
+
+	test(t, entries[1].Name, "Gold ✓")
+	test(t, entries[1].Err, nil)
+	testSlice(t, colors2hex(entries[1].Gradient.Colors(12)), colors2hex(grad2.Sharp(3, 0).Colors(12)))
+
+	test(t, entries[2].Name, "Viridis")
+	test(t, entries[2].Err, nil)
+	for i := 0; i <= 100; i++ {
+		x := float64(i) / 100
+		testTrue(t, colorDistance(Viridis().At(x), entries[2].Gradient.At(x)) < 2.0/255)
+	}
+}
+
+func Test_GrdVersion3(t *testing.T) {
+	red := Rgb(1, 0, 0, 1)
+	blue := Rgb(0, 0, 1, 1)
+
+	var buf bytes.Buffer
+	gw := &grdWriter{w: bufio.NewWriter(&buf)}
+	gw.bytes([]byte("8BGR"))
+	gw.u16(3)
+	gw.u16(1)
+	gw.bytes([]byte{5})
+	gw.bytes([]byte("Fg Bg"))
+	// Color stops: foreground, white with midpoint at 25%, background
+	gw.u16(3)
+	for _, s := range [][3]uint32{{0, 50, 1}, {2048, 50, 0}, {4096, 25, 2}} {
+		gw.u32(s[0])
+		gw.u32(s[1])
+		gw.u16(0)
+		gw.u16(65535)
+		gw.u16(65535)
+		gw.u16(65535)
+		gw.u16(0)
+		gw.u16(uint16(s[2]))
+	}
+	// Opacity stops
+	gw.u16(2)
+	for _, s := range [][3]uint32{{0, 50, 255}, {4096, 50, 0}} {
+		gw.u32(s[0])
+		gw.u32(s[1])
+		gw.u16(uint16(s[2]))
+	}
+	gw.bytes(make([]byte, 6))
+	gw.w.Flush()
+
+	entries, err := ParseGrd(&buf, red, blue)
+	test(t, err, nil)
+	test(t, len(entries), 1)
+	test(t, entries[0].Name, "Fg Bg")
+	test(t, entries[0].Err, nil)
+
+	grad := entries[0].Gradient
+	test(t, grad.At(0).HexString(), "#ff0000")
+	test(t, grad.At(0.5).HexString(), "#ffffff80")
+	test(t, grad.At(0.625).HexString(), "#8080ff60")
+	test(t, grad.At(1).HexString(), "#0000ff00")
+}
+
+func Test_GrdNoise(t *testing.T) {
+	var buf bytes.Buffer
+	gw := &grdWriter{w: bufio.NewWriter(&buf)}
+	gw.bytes([]byte("8BGR"))
+	gw.u16(5)
+	gw.u32(16)
+	gw.descriptorHeader("", "null", 1)
+	gw.key("GrdL")
+	gw.bytes([]byte("VlLs"))
+	gw.u32(1)
+	gw.bytes([]byte("Objc"))
+	gw.descriptorHeader("", "Grdn", 1)
+	gw.key("Grad")
+	gw.bytes([]byte("Objc"))
+	gw.descriptorHeader("Gradient", "Grdn", 4)
+	gw.key("Nm  ")
+	gw.bytes([]byte("TEXT"))
+	gw.unicode("Noise")
+	gw.key("GrdF")
+	gw.enum("GrdF", "ClNs")
+	gw.key("ShTr")
+	gw.bytes([]byte("bool"))
+	gw.bytes([]byte{1})
+	gw.key("Mnm ")
+	gw.bytes([]byte("VlLs"))
+	gw.u32(1)
+	gw.long(0)
+	gw.w.Flush()
+	noise := buf.Bytes()
+
+	entries, err := ParseGrd(bytes.NewReader(noise), Color{}, Color{})
+	test(t, err, nil)
+	test(t, len(entries), 1)
+	test(t, entries[0].Name, "Noise")
+	testTrue(t, errors.Is(entries[0].Err, ErrNoiseGradient))
+	testTrue(t, isZeroGradient(entries[0].Gradient))
+
+	// Invalid files
+	data := [][]byte{
+		nil,
+		[]byte("8BGR"),
+		[]byte("GIMP Gradient"),
+		[]byte("8BGR\x00\x04"),
+		noise[:20],
+	}
+	for _, b := range data {
+		_, err := ParseGrd(bytes.NewReader(b), Color{}, Color{})
+		testTrue(t, err != nil)
+	}
+}
@@ -5,11 +5,14 @@ import (
 )
 
 type linearGradient struct {
+	// Colors in the blend mode, and as given
 	colors    [][4]float64
+	input     []Color
 	positions []float64
 	min       float64
 	max       float64
 	mode      BlendMode
+	hue       HueInterpolation
 	first     Color
 	last      Color
 }
@@ -27,48 +30,31 @@ func (lg linearGradient) At(t float64) Color {
 		return Color{A: 1}
 	}
 
-	low := 0
-	high := len(lg.positions)
-
-	for low < high {
-		mid := (low + high) / 2
-		if lg.positions[mid] < t {
-			low = mid + 1
-		} else {
-			high = mid
-		}
-	}
+	return lg.segmentAt(searchStop(lg.positions, t), t)
+}
 
-	if low == 0 {
-		low = 1
-	}
+func (lg linearGradient) stopPositions() []float64 {
+	return lg.positions
+}
 
+func (lg linearGradient) segmentAt(low int, t float64) Color {
 	p1 := lg.positions[low-1]
 	p2 := lg.positions[low]
 	t = (t - p1) / (p2 - p1)
 	a, b, c, d := linearInterpolate(lg.colors[low-1], lg.colors[low], t)
 
-	switch lg.mode {
-	case BlendRgb:
-		return Color{R: a, G: b, B: c, A: d}
-	case BlendLinearRgb:
-		return LinearRgb(a, b, c, d)
-	case BlendLab:
-		return Lab(a, b, c, d).Clamp()
-	case BlendOklab:
-		return Oklab(a, b, c, d).Clamp()
-	}
-
-	return Color{}
+	return colorFromSpace(lg.mode, a, b, c, d)
 }
 
-func newLinearGradient(colors []Color, positions []float64, mode BlendMode) Gradient {
+func newLinearGradient(colors []Color, positions []float64, mode BlendMode, hue HueInterpolation) Gradient {
 	gradbase := linearGradient{
-		colors:    convertColors(colors, mode),
+		colors:    convertColors(colors, mode, hue),
+		input:     append([]Color{}, colors...),
 		positions: positions,
 		min:       positions[0],
 		max:       positions[len(positions)-1],
 		mode:      mode,
+		hue:       hue,
 		first:     colors[0],
 		last:      colors[len(colors)-1],
 	}
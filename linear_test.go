@@ -31,3 +31,38 @@ func Test_LinearGradient(t *testing.T) {
 	test(t, grad.At(1.11).HexString(), "#0000ff")
 	test(t, grad.At(math.NaN()).HexString(), "#000000")
 }
+
+func Test_PolarBlendModes(t *testing.T) {
+	data := []struct {
+		mode   BlendMode
+		hue    HueInterpolation
+		colors []string
+	}{
+		{BlendHsl, HueShorter, []string{"#ff0000", "#ff0080", "#ff00ff", "#8000ff", "#0000ff"}},
+		{BlendHsl, HueLonger, []string{"#ff0000", "#ffff00", "#00ff00", "#00ffff", "#0000ff"}},
+		{BlendHsv, HueIncreasing, []string{"#ff0000", "#ffff00", "#00ff00", "#00ffff", "#0000ff"}},
+		{BlendHwb, HueDecreasing, []string{"#ff0000", "#ff0080", "#ff00ff", "#8000ff", "#0000ff"}},
+		{BlendOklch, HueShorter, []string{"#ff0000", "#e8007b", "#ba00c2", "#7a00f4", "#0000ff"}},
+		{BlendOklch, HueLonger, []string{"#ff0000", "#bc6300", "#009300", "#0082b2", "#0000ff"}},
+		{BlendLch, HueShorter, []string{"#ff0000", "#ff0045", "#fa0080", "#c500c3", "#0000ff"}},
+	}
+	for _, d := range data {
+		grad, err := NewGradient().
+			HtmlColors("#f00", "#00f").
+			Mode(d.mode).
+			HueInterpolation(d.hue).
+			Build()
+
+		test(t, err, nil)
+		testSlice(t, colors2hex(grad.Colors(5)), d.colors)
+	}
+
+	// Achromatic stops take the hue of their neighbour
+	grad, err := NewGradient().
+		HtmlColors("#fff", "#f00", "#000").
+		Mode(BlendOklch).
+		Build()
+
+	test(t, err, nil)
+	testSlice(t, colors2hex(grad.Colors(5)), []string{"#ffffff", "#ffa191", "#ff0000", "#630000", "#000000"})
+}
@@ -0,0 +1,90 @@
+package colorgrad
+
+import (
+	"image/color"
+	"math"
+)
+
+type lutGradient struct {
+	colors      []Color
+	nan         Color
+	min         float64
+	max         float64
+	interpolate bool
+}
+
+func (lg lutGradient) At(t float64) Color {
+	if t <= lg.min {
+		return lg.colors[0]
+	}
+
+	if t >= lg.max {
+		return lg.colors[len(lg.colors)-1]
+	}
+
+	if math.IsNaN(t) {
+		return lg.nan
+	}
+
+	x := (t - lg.min) / (lg.max - lg.min) * float64(len(lg.colors)-1)
+
+	if !lg.interpolate {
+		return lg.colors[int(x+0.5)]
+	}
+
+	// x may round up to the last index just below max
+	i := int(x)
+	if i >= len(lg.colors)-1 {
+		return lg.colors[len(lg.colors)-1]
+	}
+	return blendRgb(lg.colors[i], lg.colors[i+1], x-float64(i))
+}
+
+// Return a gradient sampling a table of n colors evenly spaced across the
+// gradient, with nearest or linear interpolation between them. Much faster
+// than the smooth interpolations and the blend modes other than RGB, at the
+// cost of accuracy.
+func (g Gradient) Lut(n uint, interpolate bool) Gradient {
+	if n < 2 {
+		n = 2
+	}
+	colors := make([]Color, n)
+	for i, t := range linspace(g.Min, g.Max, n) {
+		colors[i] = g.Core.At(t)
+	}
+	return Gradient{
+		Core: lutGradient{
+			colors:      colors,
+			nan:         g.Core.At(math.NaN()),
+			min:         g.Min,
+			max:         g.Max,
+			interpolate: interpolate,
+		},
+		Min:   g.Min,
+		Max:   g.Max,
+		NaN:   g.NaN,
+		Under: g.Under,
+		Over:  g.Over,
+	}
+}
+
+// Get n colors evenly spaced across gradient, as alpha-premultiplied
+// color.RGBA
+func (g Gradient) RGBAColors(count uint) []color.RGBA {
+	res := make([]color.RGBA, count)
+	for i, c := range g.Colors(count) {
+		res[i] = color.RGBAModel.Convert(c).(color.RGBA)
+	}
+	return res
+}
+
+// Get n colors evenly spaced across gradient, as 0xRRGGBBAA values (not
+// alpha-premultiplied)
+func (g Gradient) Uint32Colors(count uint) []uint32 {
+	res := make([]uint32, count)
+	for i, c := range g.Colors(count) {
+		r, gr, b, a := c.RGBA255()
+		res[i] = uint32(r)<<24 | uint32(gr)<<16 | uint32(b)<<8 | uint32(a)
+	}
+	return res
+}
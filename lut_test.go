@@ -0,0 +1,56 @@
+package colorgrad
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func Test_Lut(t *testing.T) {
+	grad, _ := NewGradient().
+		HtmlColors("#000", "#fff").
+		Domain(-1, 1).
+		NaNColor(Rgb(1, 0, 0, 1)).
+		Build()
+
+	lut := grad.Lut(3, false)
+	test(t, lut.Min, -1.0)
+	test(t, lut.Max, 1.0)
+	test(t, lut.At(-1).HexString(), "#000000")
+	test(t, lut.At(-0.6).HexString(), "#000000")
+	test(t, lut.At(-0.4).HexString(), "#808080")
+	test(t, lut.At(0.6).HexString(), "#ffffff")
+	test(t, lut.At(2).HexString(), "#ffffff")
+	test(t, lut.At(math.NaN()).HexString(), "#ff0000")
+	test(t, lut.Core.At(math.NaN()).HexString(), grad.Core.At(math.NaN()).HexString())
+
+	lut = grad.Lut(3, true)
+	test(t, lut.At(-0.5).HexString(), "#404040")
+	test(t, lut.At(0.5).HexString(), "#bfbfbf")
+	testSlice(t, colors2hex(lut.Colors(5)), colors2hex(grad.Colors(5)))
+
+	lut = Turbo().Lut(256, true)
+	for _, x := range []float64{0, 0.1, 0.33, 0.5, 0.77, 1} {
+		testTrue(t, colorDistance(lut.At(x), Turbo().At(x)) < 1.0/255)
+	}
+
+	testSlice(t, colors2hex(grad.Lut(0, false).Colors(2)), []string{"#000000", "#ffffff"})
+
+	// Just below max
+	grad, _ = NewGradient().HtmlColors("#000", "#fff").Domain(-3, 7).Build()
+	below := math.Nextafter(7, math.Inf(-1))
+	for _, n := range []uint{2, 3, 7, 256} {
+		test(t, grad.Lut(n, true).At(below).HexString(), "#ffffff")
+		test(t, grad.Lut(n, false).At(below).HexString(), "#ffffff")
+	}
+}
+
+func Test_RGBAColors(t *testing.T) {
+	grad, _ := NewGradient().
+		Colors(Rgb(1, 0, 0, 0), Rgb(0, 0, 1, 1)).
+		Build()
+
+	testSlice(t, grad.RGBAColors(3), []color.RGBA{{0, 0, 0, 0}, {64, 0, 64, 128}, {0, 0, 255, 255}})
+	testSlice(t, grad.Uint32Colors(3), []uint32{0xff000000, 0x80008080, 0x0000ffff})
+	test(t, len(grad.Uint32Colors(0)), 0)
+}
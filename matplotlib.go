@@ -0,0 +1,340 @@
+package colorgrad
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Reference: https://matplotlib.org/stable/api/_as_gen/matplotlib.colors.LinearSegmentedColormap.html
+
+var matplotlibChannels = []string{"red", "green", "blue", "alpha"}
+
+// ParseMatplotlibDict reads the segment data of a matplotlib
+// LinearSegmentedColormap, written as a Python or JSON dict literal:
+//
+//	{'red': [(0, 0, 0), (1, 1, 1)], 'green': ..., 'blue': ..., 'alpha': ...}
+//
+// Each row (x, y0, y1) gives the value y0 left of x and y1 right of it. The
+// alpha channel is optional. Channels given as functions are not supported.
+func ParseMatplotlibDict(r io.Reader) (Colormap, error) {
+	cm := Colormap{
+		Gradient: Gradient{
+			Core: zeroGradient{},
+			Min:  0,
+			Max:  1,
+		},
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return cm, err
+	}
+
+	p := &pyParser{s: string(data)}
+	value, err := p.parse()
+	if err != nil {
+		return cm, err
+	}
+	dict, ok := value.(map[string]any)
+	if !ok {
+		return cm, fmt.Errorf("invalid matplotlib segment data: expected a dict")
+	}
+
+	// Rows of each channel, and all x values
+	rows := make([][][3]float64, len(matplotlibChannels))
+	xs := []float64{}
+
+	for ch, name := range matplotlibChannels {
+		v, ok := dict[name]
+		if !ok {
+			if name == "alpha" {
+				rows[ch] = [][3]float64{{0, 1, 1}, {1, 1, 1}}
+				continue
+			}
+			return cm, fmt.Errorf("invalid matplotlib segment data: missing %q", name)
+		}
+		list, ok := v.([]any)
+		if !ok || len(list) < 2 {
+			return cm, fmt.Errorf("invalid matplotlib segment data: %q: expected at least 2 rows", name)
+		}
+		for i, item := range list {
+			row, ok := item.([]any)
+			if !ok || len(row) != 3 {
+				return cm, fmt.Errorf("invalid matplotlib segment data: %q: expected rows of 3 numbers", name)
+			}
+			var vals [3]float64
+			for j, x := range row {
+				f, ok := x.(float64)
+				if !ok {
+					return cm, fmt.Errorf("invalid matplotlib segment data: %q: expected rows of 3 numbers", name)
+				}
+				vals[j] = f
+			}
+			if i > 0 && vals[0] < rows[ch][i-1][0] {
+				return cm, fmt.Errorf("invalid matplotlib segment data: %q: decreasing x value %v", name, vals[0])
+			}
+			rows[ch] = append(rows[ch], vals)
+			xs = append(xs, vals[0])
+		}
+		if rows[ch][0][0] != 0 || rows[ch][len(list)-1][0] != 1 {
+			return cm, fmt.Errorf("invalid matplotlib segment data: %q: x must start at 0 and end at 1", name)
+		}
+	}
+
+	sort.Float64s(xs)
+
+	colors := []Color{}
+	positions := []float64{}
+	for i, x := range xs {
+		if i > 0 && x == xs[i-1] {
+			continue
+		}
+		var left, right [4]float64
+		for ch := range rows {
+			left[ch], right[ch] = matplotlibChannelAt(rows[ch], x)
+		}
+		colors = append(colors, Rgb(left[0], left[1], left[2], left[3]))
+		positions = append(positions, x)
+		if left != right {
+			colors = append(colors, Rgb(right[0], right[1], right[2], right[3]))
+			positions = append(positions, x)
+		}
+	}
+
+	// The values left of 0 and right of 1 are unused
+	if len(positions) > 1 && positions[1] == 0 {
+		colors, positions = colors[1:], positions[1:]
+	}
+	if n := len(positions); n > 1 && positions[n-2] == 1 {
+		colors, positions = colors[:n-1], positions[:n-1]
+	}
+
+	cm.Gradient = Gradient{
+		Core: gimpGradient{
+			segments: stopSegments(colors, positions),
+			min:      0,
+			max:      1,
+		},
+		Min: 0,
+		Max: 1,
+	}
+
+	cm.Discrete = true
+	for _, seg := range cm.Gradient.Core.(gimpGradient).segments {
+		if seg.lcolor != seg.rcolor {
+			cm.Discrete = false
+			break
+		}
+	}
+	return cm, nil
+}
+
+// Values of a channel left and right of x
+func matplotlibChannelAt(rows [][3]float64, x float64) (float64, float64) {
+	i := sort.Search(len(rows), func(i int) bool { return rows[i][0] >= x })
+	if rows[i][0] == x {
+		// Left value of the first row and right value of the last row at x
+		j := i
+		for j+1 < len(rows) && rows[j+1][0] == x {
+			j++
+		}
+		return rows[i][1], rows[j][2]
+	}
+	a := rows[i-1]
+	b := rows[i]
+	t := (x - a[0]) / (b[0] - a[0])
+	v := a[2] + t*(b[1]-a[2])
+	return v, v
+}
+
+// WriteMatplotlibDict writes the gradient of cm as matplotlib segment data, a
+// Python dict literal which can be passed to LinearSegmentedColormap. The
+// domain is mapped to [0..1]. The alpha channel is only written if the
+// gradient is not opaque. Gradients are written exactly or approximated as in
+// WriteCpt.
+func WriteMatplotlibDict(w io.Writer, cm Colormap) error {
+	g := cm.Gradient
+	colors, positions := segmentStops(colormapSegments(g))
+
+	// Rows with the values left and right of each position
+	type row struct {
+		x           float64
+		left, right Color
+	}
+	rows := []row{}
+	for i, c := range colors {
+		x := norm(positions[i], g.Min, g.Max)
+		if n := len(rows); n > 0 && positions[i] == positions[i-1] {
+			rows[n-1].right = c
+			continue
+		}
+		rows = append(rows, row{x, c, c})
+	}
+	rows[0].x = 0
+	rows[len(rows)-1].x = 1
+
+	opaque := true
+	for _, c := range colors {
+		if c.A < 1 {
+			opaque = false
+			break
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("{\n")
+	for ch, name := range matplotlibChannels {
+		if name == "alpha" && opaque {
+			break
+		}
+		fmt.Fprintf(bw, "    '%s': [\n", name)
+		for _, r := range rows {
+			l := [4]float64{r.left.R, r.left.G, r.left.B, r.left.A}
+			rr := [4]float64{r.right.R, r.right.G, r.right.B, r.right.A}
+			fmt.Fprintf(bw, "        (%s, %s, %s),\n", formatFloat(r.x, 8), formatFloat(l[ch], 8), formatFloat(rr[ch], 8))
+		}
+		bw.WriteString("    ],\n")
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+// Parser for the subset of Python literals used by segment data: dicts with
+// string keys, lists, tuples and numbers. Dicts are returned as
+// map[string]any, lists and tuples as []any, numbers as float64.
+type pyParser struct {
+	s   string
+	pos int
+}
+
+func (p *pyParser) errorf(format string, a ...any) error {
+	return fmt.Errorf("invalid matplotlib segment data: %s at offset %d", fmt.Sprintf(format, a...), p.pos)
+}
+
+func (p *pyParser) skipSpace() {
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if c == '#' {
+			for p.pos < len(p.s) && p.s[p.pos] != '\n' {
+				p.pos++
+			}
+			continue
+		}
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+			return
+		}
+		p.pos++
+	}
+}
+
+func (p *pyParser) parse() (any, error) {
+	v, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos])
+	}
+	return v, nil
+}
+
+func (p *pyParser) value() (any, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return nil, p.errorf("unexpected end of input")
+	}
+
+	switch c := p.s[p.pos]; c {
+	case '{':
+		p.pos++
+		dict := map[string]any{}
+		for {
+			p.skipSpace()
+			if p.pos < len(p.s) && p.s[p.pos] == '}' {
+				p.pos++
+				return dict, nil
+			}
+			key, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			k, ok := key.(string)
+			if !ok {
+				return nil, p.errorf("expected string key")
+			}
+			p.skipSpace()
+			if p.pos >= len(p.s) || p.s[p.pos] != ':' {
+				return nil, p.errorf("expected ':'")
+			}
+			p.pos++
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			dict[k] = v
+			if err := p.separator('}'); err != nil {
+				return nil, err
+			}
+		}
+	case '[', '(':
+		end := byte(']')
+		if c == '(' {
+			end = ')'
+		}
+		p.pos++
+		list := []any{}
+		for {
+			p.skipSpace()
+			if p.pos < len(p.s) && p.s[p.pos] == end {
+				p.pos++
+				return list, nil
+			}
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+			if err := p.separator(end); err != nil {
+				return nil, err
+			}
+		}
+	case '\'', '"':
+		end := strings.IndexByte(p.s[p.pos+1:], c)
+		if end < 0 {
+			return nil, p.errorf("unterminated string")
+		}
+		s := p.s[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return s, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("+-.0123456789eE", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	f, ok := parseFloat(p.s[start:p.pos])
+	if !ok {
+		p.pos = start
+		return nil, p.errorf("invalid value")
+	}
+	return f, nil
+}
+
+// Consume a comma, or leave the closing bracket to the caller
+func (p *pyParser) separator(end byte) error {
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		switch p.s[p.pos] {
+		case ',':
+			p.pos++
+			return nil
+		case end:
+			return nil
+		}
+	}
+	return p.errorf("expected ',' or %q", end)
+}
@@ -0,0 +1,92 @@
+package colorgrad
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_ParseMatplotlibDict(t *testing.T) {
+	// From the matplotlib documentation
+	cdict := `{'red':   [(0.0,  0.0, 0.0),
+                   (0.5,  1.0, 1.0),
+                   (1.0,  1.0, 1.0)],
+         'green': [(0.0,  0.0, 0.0),
+                   (0.25, 0.0, 0.0),
+                   (0.75, 1.0, 1.0),
+                   (1.0,  1.0, 1.0)],
+         'blue':  [(0.0,  0.0, 0.0),
+                   (0.5,  0.0, 0.0),
+                   (1.0,  1.0, 1.0)],  # trailing comma
+        }`
+
+	cm, err := ParseMatplotlibDict(strings.NewReader(cdict))
+	test(t, err, nil)
+	test(t, cm.Discrete, false)
+	testSlice(t, colors2hex(cm.Gradient.Colors(5)), []string{"#000000", "#800000", "#ff8000", "#ffff80", "#ffffff"})
+
+	// Discontinuity, JSON
+	cdict = `{"red": [[0, 0, 0], [0.5, 0, 1], [1, 1, 1]],
+		"green": [[0, 0, 0], [1, 0, 0]],
+		"blue": [[0, 0, 1], [0.5, 1, 0], [1, 0, 0]],
+		"alpha": [[0, 0.5, 0.5], [1, 0.5, 0.5]]}`
+	cm, err = ParseMatplotlibDict(strings.NewReader(cdict))
+	test(t, err, nil)
+	test(t, cm.Discrete, true)
+	test(t, cm.Gradient.At(0.49).HexString(), "#0000ff80")
+	test(t, cm.Gradient.At(0.51).HexString(), "#ff000080")
+
+	// Invalid
+	data := []string{
+		"",
+		"[]",
+		"{'red': [(0, 0, 0), (1, 1, 1)]}",
+		"{'red': [(0, 0, 0), (1, 1, 1)], 'green': [(0, 0, 0), (1, 1, 1)], 'blue': [(0, 0, 0), (0.9, 1, 1)]}",
+		"{'red': [(0, 0, 0), (1, 1, 1)], 'green': [(0, 0, 0), (1, 1, 1)], 'blue': [(0, 0), (1, 1, 1)]}",
+		"{'red': [(0, 0, 0), (1, 1, 1)], 'green': [(0, 0, 0), (1, 1, 1)], 'blue': [(0, 0, 0), (1, 1, 1)]",
+		"{'red': [(0, 0, 0), (1, 1, 1)], 'green': [(0, 0, 0), (1, 1, 1)], 'blue': [(0, 0, 0), (1, x, 1)]}",
+	}
+	for _, s := range data {
+		_, err := ParseMatplotlibDict(strings.NewReader(s))
+		testTrue(t, err != nil)
+	}
+}
+
+func Test_WriteMatplotlibDict(t *testing.T) {
+	grad, _ := NewGradient().HtmlColors("black", "red").Domain(10, 20).Build()
+
+	var buf bytes.Buffer
+	err := WriteMatplotlibDict(&buf, Colormap{Gradient: grad.Sharp(2, 0)})
+	test(t, err, nil)
+	test(t, buf.String(), `{
+    'red': [
+        (0, 0, 0),
+        (0.5, 0, 1),
+        (1, 1, 1),
+    ],
+    'green': [
+        (0, 0, 0),
+        (0.5, 0, 0),
+        (1, 0, 0),
+    ],
+    'blue': [
+        (0, 0, 0),
+        (0.5, 0, 0),
+        (1, 0, 0),
+    ],
+}
+`)
+
+	cm, err := ParseMatplotlibDict(&buf)
+	test(t, err, nil)
+	test(t, cm.Discrete, true)
+	test(t, cm.Gradient.At(0.25).HexString(), "#000000")
+	test(t, cm.Gradient.At(0.75).HexString(), "#ff0000")
+
+	// With alpha
+	grad, _ = NewGradient().Colors(Rgb(1, 0, 0, 0), Rgb(1, 0, 0, 1)).Build()
+	buf.Reset()
+	err = WriteMatplotlibDict(&buf, Colormap{Gradient: grad})
+	test(t, err, nil)
+	testTrue(t, strings.Contains(buf.String(), "'alpha': [\n        (0, 0, 0),\n        (1, 1, 1),\n    ],"))
+}
@@ -0,0 +1,229 @@
+package colorgrad
+
+import (
+	"math"
+	"sort"
+)
+
+// Norm maps data values to [0..1] and back. Values outside the data range
+// map outside [0..1], invalid values map to NaN.
+type Norm interface {
+	// Map a data value to [0..1]
+	Normalize(v float64) float64
+	// Map a value in [0..1] back to the data range
+	Inverse(t float64) float64
+}
+
+// Linear mapping of [Vmin..Vmax] to [0..1]
+type LinearNorm struct {
+	Vmin float64
+	Vmax float64
+}
+
+func (n LinearNorm) Normalize(v float64) float64 {
+	return norm(v, n.Vmin, n.Vmax)
+}
+
+func (n LinearNorm) Inverse(t float64) float64 {
+	return n.Vmin + t*(n.Vmax-n.Vmin)
+}
+
+// Logarithmic mapping of [Vmin..Vmax] to [0..1]. Vmin must be positive,
+// values <= 0 map to NaN.
+type LogNorm struct {
+	Vmin float64
+	Vmax float64
+}
+
+func (n LogNorm) Normalize(v float64) float64 {
+	if v <= 0 || n.Vmin <= 0 {
+		return math.NaN()
+	}
+	return math.Log(v/n.Vmin) / math.Log(n.Vmax/n.Vmin)
+}
+
+func (n LogNorm) Inverse(t float64) float64 {
+	return n.Vmin * math.Pow(n.Vmax/n.Vmin, t)
+}
+
+// Symmetric logarithmic mapping of [Vmin..Vmax] to [0..1], linear in
+// [-LinThresh..LinThresh] and logarithmic outside, as in matplotlib.
+// LinScale is the size of the linear range in decades, Base defaults to 10.
+type SymLogNorm struct {
+	LinThresh float64
+	LinScale  float64
+	Vmin      float64
+	Vmax      float64
+	Base      float64
+}
+
+func (n SymLogNorm) base() float64 {
+	if n.Base <= 0 {
+		return 10
+	}
+	return n.Base
+}
+
+// Scale of the linear range
+func (n SymLogNorm) linScale() float64 {
+	return n.LinScale / (1 - 1/n.base())
+}
+
+func (n SymLogNorm) transform(v float64) float64 {
+	a := math.Abs(v)
+	if a <= n.LinThresh {
+		return v * n.linScale()
+	}
+	return math.Copysign(n.LinThresh*(n.linScale()+math.Log(a/n.LinThresh)/math.Log(n.base())), v)
+}
+
+func (n SymLogNorm) inverseTransform(x float64) float64 {
+	a := math.Abs(x)
+	if a <= n.LinThresh*n.linScale() {
+		return x / n.linScale()
+	}
+	return math.Copysign(n.LinThresh*math.Pow(n.base(), a/n.LinThresh-n.linScale()), x)
+}
+
+func (n SymLogNorm) Normalize(v float64) float64 {
+	if n.LinThresh <= 0 {
+		return math.NaN()
+	}
+	return norm(n.transform(v), n.transform(n.Vmin), n.transform(n.Vmax))
+}
+
+func (n SymLogNorm) Inverse(t float64) float64 {
+	a := n.transform(n.Vmin)
+	b := n.transform(n.Vmax)
+	return n.inverseTransform(a + t*(b-a))
+}
+
+// Power-law mapping of [Vmin..Vmax] to [0..1], ((v - Vmin) / (Vmax - Vmin))
+// raised to Gamma. As in matplotlib without clip, values below Vmin are not
+// clipped to 0 but map linearly below 0, so they get the Under color. For
+// clip=True, clamp the values to [Vmin..Vmax] first.
+type PowerNorm struct {
+	Gamma float64
+	Vmin  float64
+	Vmax  float64
+}
+
+func (n PowerNorm) Normalize(v float64) float64 {
+	t := norm(v, n.Vmin, n.Vmax)
+	if t <= 0 {
+		return t
+	}
+	return math.Pow(t, n.Gamma)
+}
+
+func (n PowerNorm) Inverse(t float64) float64 {
+	if t > 0 {
+		t = math.Pow(t, 1/n.Gamma)
+	}
+	return n.Vmin + t*(n.Vmax-n.Vmin)
+}
+
+// Two linear mappings with different slopes: [Vmin..Vcenter] to [0..0.5] and
+// [Vcenter..Vmax] to [0.5..1].
+type TwoSlopeNorm struct {
+	Vmin    float64
+	Vcenter float64
+	Vmax    float64
+}
+
+func (n TwoSlopeNorm) Normalize(v float64) float64 {
+	if !(n.Vmin < n.Vcenter && n.Vcenter < n.Vmax) {
+		return math.NaN()
+	}
+	if v < n.Vcenter {
+		return norm(v, n.Vmin, n.Vcenter) / 2
+	}
+	return 0.5 + norm(v, n.Vcenter, n.Vmax)/2
+}
+
+func (n TwoSlopeNorm) Inverse(t float64) float64 {
+	if t < 0.5 {
+		return n.Vmin + t*2*(n.Vcenter-n.Vmin)
+	}
+	return n.Vcenter + (t-0.5)*2*(n.Vmax-n.Vcenter)
+}
+
+// Discrete mapping to bins. Boundaries are the increasing bin edges, values
+// in bin i of n map to the center of the bin, (i + 0.5) / n. Values below the
+// first edge map to -1, values above the last edge map to 2. Inverse maps the
+// bin edges i / n back to the boundaries, and is linear between them, for
+// colorbar ticks.
+type BoundaryNorm struct {
+	Boundaries []float64
+}
+
+func (n BoundaryNorm) Normalize(v float64) float64 {
+	b := n.Boundaries
+	nbins := len(b) - 1
+	if nbins < 1 || math.IsNaN(v) {
+		return math.NaN()
+	}
+	if v < b[0] {
+		return -1
+	}
+	if v > b[nbins] {
+		return 2
+	}
+	i := sort.SearchFloat64s(b, v)
+	if i < len(b) && b[i] == v {
+		// Right-open bins, but the last edge belongs to the last bin
+		i++
+	}
+	if i < 1 {
+		i = 1
+	} else if i > nbins {
+		i = nbins
+	}
+	return (float64(i) - 0.5) / float64(nbins)
+}
+
+func (n BoundaryNorm) Inverse(t float64) float64 {
+	b := n.Boundaries
+	nbins := len(b) - 1
+	if nbins < 1 {
+		return math.NaN()
+	}
+	x := t * float64(nbins)
+	i := int(math.Floor(x))
+	if i < 0 {
+		i = 0
+	} else if i > nbins-1 {
+		i = nbins - 1
+	}
+	return b[i] + (x-float64(i))*(b[i+1]-b[i])
+}
+
+// NormGradient maps data values through a Norm to the domain of a Gradient.
+// Data values which normalize outside [0..1] get the Under and Over colors of
+// the gradient, invalid values get the NaN color.
+type NormGradient struct {
+	Gradient Gradient
+	Norm     Norm
+}
+
+// Return a gradient which maps data values through n
+func (g Gradient) WithNorm(n Norm) NormGradient {
+	return NormGradient{Gradient: g, Norm: n}
+}
+
+// Get color for a data value
+func (ng NormGradient) At(v float64) Color {
+	g := ng.Gradient
+	t := ng.Norm.Normalize(v)
+	pos := g.Min + t*(g.Max-g.Min)
+	// Values in the data range stay in the domain despite rounding
+	if t >= 0 && t <= 1 {
+		pos = math.Max(g.Min, math.Min(g.Max, pos))
+	}
+	return g.At(pos)
+}
+
+// Data value for a value in [0..1], such as the position of a colorbar tick
+func (ng NormGradient) Inverse(t float64) float64 {
+	return ng.Norm.Inverse(t)
+}
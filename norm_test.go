@@ -0,0 +1,96 @@
+package colorgrad
+
+import (
+	"math"
+	"testing"
+)
+
+func testNorm(t *testing.T, n Norm, values, expected []float64) {
+	t.Helper()
+	for i, v := range values {
+		x := n.Normalize(v)
+		if math.Abs(x-expected[i]) > 1e-9 {
+			t.Errorf("%T: Normalize(%v) = %v, expected %v", n, v, x, expected[i])
+		}
+		if x < 0 || x > 1 {
+			continue
+		}
+		if y := n.Inverse(x); math.Abs(y-v) > 1e-9*math.Max(1, math.Abs(v)) {
+			t.Errorf("%T: Inverse(%v) = %v, expected %v", n, x, y, v)
+		}
+	}
+}
+
+func Test_Norm(t *testing.T) {
+	testNorm(t, LinearNorm{-10, 10}, []float64{-20, -10, 0, 5, 10}, []float64{-0.5, 0, 0.5, 0.75, 1})
+	testNorm(t, LogNorm{1, 1000}, []float64{1, 10, 100, 1000, 10000}, []float64{0, 1.0 / 3, 2.0 / 3, 1, 4.0 / 3})
+	testNorm(t, PowerNorm{2, 0, 10}, []float64{-5, 0, 5, 10}, []float64{-0.5, 0, 0.25, 1})
+	testNorm(t, PowerNorm{0.5, 10, 110}, []float64{-15, 10, 35, 110}, []float64{-0.25, 0, 0.5, 1})
+	testNorm(t, TwoSlopeNorm{-1, 0, 10}, []float64{-1, -0.5, 0, 5, 10}, []float64{0, 0.25, 0.5, 0.75, 1})
+
+	// Linear range [-1..1] is one decade wide in base 10, transform(1) = 1/0.9
+	sl := SymLogNorm{LinThresh: 1, LinScale: 1, Vmin: -100, Vmax: 100}
+	a := 1 / 0.9
+	tr := []float64{-(a + 2), -(a + 1), -a, 0, a / 2, a, a + 1, a + 2}
+	expected := make([]float64, len(tr))
+	for i, x := range tr {
+		expected[i] = (x + a + 2) / (2 * (a + 2))
+	}
+	testNorm(t, sl, []float64{-100, -10, -1, 0, 0.5, 1, 10, 100}, expected)
+
+	bn := BoundaryNorm{[]float64{0, 1, 10, 100}}
+	values := []float64{-1, 0, 0.5, 1, 50, 100, 101}
+	expected = []float64{-1, 1.0 / 6, 1.0 / 6, 0.5, 5.0 / 6, 5.0 / 6, 2}
+	for i, v := range values {
+		test(t, bn.Normalize(v), expected[i])
+	}
+	testSliceF(t, []float64{bn.Inverse(0), bn.Inverse(1.0 / 3), bn.Inverse(0.5), bn.Inverse(1)}, []float64{0, 1, 5.5, 100})
+
+	// Invalid
+	testTrue(t, math.IsNaN(LogNorm{1, 100}.Normalize(0)))
+	testTrue(t, math.IsNaN(LogNorm{0, 100}.Normalize(1)))
+	testTrue(t, math.IsNaN(TwoSlopeNorm{0, 0, 1}.Normalize(0.5)))
+	testTrue(t, math.IsNaN(SymLogNorm{Vmin: -1, Vmax: 1}.Normalize(0.5)))
+	testTrue(t, math.IsNaN(BoundaryNorm{[]float64{1}}.Normalize(1)))
+	testTrue(t, math.IsNaN(BoundaryNorm{[]float64{0, 1}}.Normalize(math.NaN())))
+}
+
+func Test_NormGradient(t *testing.T) {
+	grad, _ := NewGradient().
+		HtmlColors("black", "white").
+		Domain(-1, 1).
+		NaNColor(Rgb(1, 0, 0, 1)).
+		UnderColor(Rgb(0, 1, 0, 1)).
+		OverColor(Rgb(0, 0, 1, 1)).
+		Build()
+
+	ng := grad.WithNorm(LogNorm{1, 100})
+	test(t, ng.At(1).HexString(), "#000000")
+	test(t, ng.At(10).HexString(), "#808080")
+	test(t, ng.At(100).HexString(), "#ffffff")
+	test(t, ng.At(0.5).HexString(), "#00ff00")
+	test(t, ng.At(1000).HexString(), "#0000ff")
+	test(t, ng.At(-1).HexString(), "#ff0000")
+	test(t, ng.At(math.NaN()).HexString(), "#ff0000")
+	test(t, ng.Inverse(0.5), 10.0)
+
+	// Vmax maps to max, not past it
+	grad2, _ := NewGradient().
+		HtmlColors("black", "white").
+		Domain(0.3, 0.9).
+		OverColor(Rgb(0, 0, 1, 1)).
+		Build()
+	test(t, grad2.WithNorm(LinearNorm{0.3, 0.9}).At(0.9).HexString(), "#ffffff")
+
+	// Below Vmin, not clipped
+	ng = grad.WithNorm(PowerNorm{2, 10, 110})
+	test(t, ng.At(10).HexString(), "#000000")
+	test(t, ng.At(9).HexString(), "#00ff00")
+
+	ng = grad.Sharp(3, 0).WithNorm(BoundaryNorm{[]float64{0, 10, 20, 50}})
+	test(t, ng.At(5).HexString(), "#000000")
+	test(t, ng.At(15).HexString(), "#808080")
+	test(t, ng.At(49).HexString(), "#ffffff")
+	test(t, ng.At(-1).HexString(), "#00ff00")
+	test(t, ng.At(51).HexString(), "#0000ff")
+}
@@ -0,0 +1,300 @@
+package colorgrad
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// Reference: https://www.paraview.org/Wiki/Colormaps
+
+type paraviewXmlColor struct {
+	R float64 `xml:"r,attr"`
+	G float64 `xml:"g,attr"`
+	B float64 `xml:"b,attr"`
+}
+
+type paraviewXmlPoint struct {
+	X float64 `xml:"x,attr"`
+	O float64 `xml:"o,attr"`
+	paraviewXmlColor
+}
+
+type paraviewXmlMap struct {
+	XMLName xml.Name           `xml:"ColorMap"`
+	Name    string             `xml:"name,attr,omitempty"`
+	Space   string             `xml:"space,attr,omitempty"`
+	Points  []paraviewXmlPoint `xml:"Point"`
+	NaN     *paraviewXmlColor  `xml:"NaN"`
+}
+
+type paraviewXmlFile struct {
+	XMLName xml.Name         `xml:"ColorMaps"`
+	Maps    []paraviewXmlMap `xml:"ColorMap"`
+}
+
+type paraviewJsonMap struct {
+	Name               string    `json:",omitempty"`
+	ColorSpace         string    `json:",omitempty"`
+	NanColor           []float64 `json:",omitempty"`
+	BelowRangeColor    []float64 `json:",omitempty"`
+	UseBelowRangeColor *bool     `json:",omitempty"`
+	AboveRangeColor    []float64 `json:",omitempty"`
+	UseAboveRangeColor *bool     `json:",omitempty"`
+	RGBPoints          []float64
+}
+
+// ParseParaViewXml reads the <ColorMap> elements of a ParaView XML colormap
+// file. The gradient domain is the range of the point x values. The RGB, Lab
+// and HSV color spaces are supported, Diverging is approximated by Lab, and
+// Step switches color halfway between points. Opacity is ignored.
+func ParseParaViewXml(r io.Reader) ([]Colormap, error) {
+	decoder := xml.NewDecoder(r)
+	colormaps := []Colormap{}
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid ParaView XML: %w", err)
+		}
+		el, ok := tok.(xml.StartElement)
+		if !ok || el.Name.Local != "ColorMap" {
+			continue
+		}
+
+		var m paraviewXmlMap
+		if err := decoder.DecodeElement(&m, &el); err != nil {
+			return nil, fmt.Errorf("invalid ParaView XML: %w", err)
+		}
+
+		xs := make([]float64, len(m.Points))
+		colors := make([]Color, len(m.Points))
+		for i, p := range m.Points {
+			xs[i] = p.X
+			colors[i] = Rgb(p.R, p.G, p.B, 1)
+		}
+		cm, err := paraviewColormap(m.Name, m.Space, xs, colors)
+		if err != nil {
+			return nil, err
+		}
+		if m.NaN != nil {
+			c := Rgb(m.NaN.R, m.NaN.G, m.NaN.B, 1)
+			cm.NaN = &c
+		}
+		cm.applyColors()
+		colormaps = append(colormaps, cm)
+	}
+
+	if len(colormaps) == 0 {
+		return nil, fmt.Errorf("invalid ParaView XML: no colormap found")
+	}
+	return colormaps, nil
+}
+
+// ParseParaViewJson reads a ParaView JSON colormap file, either a single
+// colormap or an array of them. The color spaces are handled as in
+// ParseParaViewXml. The NaN, below range and above range colors set the NaN,
+// background and foreground colors.
+func ParseParaViewJson(r io.Reader) ([]Colormap, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var maps []paraviewJsonMap
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		maps = make([]paraviewJsonMap, 1)
+		err = json.Unmarshal(data, &maps[0])
+	} else {
+		err = json.Unmarshal(data, &maps)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid ParaView JSON: %w", err)
+	}
+	if len(maps) == 0 {
+		return nil, fmt.Errorf("invalid ParaView JSON: no colormap found")
+	}
+
+	colormaps := make([]Colormap, len(maps))
+	for i, m := range maps {
+		if len(m.RGBPoints)%4 != 0 {
+			return nil, fmt.Errorf("invalid ParaView JSON: %q: RGBPoints length is not a multiple of 4", m.Name)
+		}
+		n := len(m.RGBPoints) / 4
+		xs := make([]float64, n)
+		colors := make([]Color, n)
+		for j := 0; j < n; j++ {
+			p := m.RGBPoints[j*4 : j*4+4]
+			xs[j] = p[0]
+			colors[j] = Rgb(p[1], p[2], p[3], 1)
+		}
+		cm, err := paraviewColormap(m.Name, m.ColorSpace, xs, colors)
+		if err != nil {
+			return nil, err
+		}
+		cm.NaN, err = paraviewJsonColor(m.Name, m.NanColor, nil)
+		if err == nil {
+			cm.Background, err = paraviewJsonColor(m.Name, m.BelowRangeColor, m.UseBelowRangeColor)
+		}
+		if err == nil {
+			cm.Foreground, err = paraviewJsonColor(m.Name, m.AboveRangeColor, m.UseAboveRangeColor)
+		}
+		if err != nil {
+			return nil, err
+		}
+		cm.applyColors()
+		colormaps[i] = cm
+	}
+	return colormaps, nil
+}
+
+func paraviewJsonColor(name string, v []float64, use *bool) (*Color, error) {
+	if len(v) == 0 || (use != nil && !*use) {
+		return nil, nil
+	}
+	if len(v) != 3 {
+		return nil, fmt.Errorf("invalid ParaView JSON: %q: invalid color %v", name, v)
+	}
+	c := Rgb(v[0], v[1], v[2], 1)
+	return &c, nil
+}
+
+func paraviewColormap(name, space string, xs []float64, colors []Color) (Colormap, error) {
+	cm := Colormap{Name: name}
+
+	if len(xs) == 0 {
+		return cm, fmt.Errorf("invalid ParaView colormap %q: no points", name)
+	}
+	for i := 1; i < len(xs); i++ {
+		if xs[i] < xs[i-1] {
+			return cm, fmt.Errorf("invalid ParaView colormap %q: decreasing x value %v", name, xs[i])
+		}
+	}
+
+	mode := BlendRgb
+	switch space {
+	case "", "RGB":
+	case "Lab", "Lab/CIEDE2000", "Diverging":
+		mode = BlendLab
+	case "HSV":
+		mode = BlendHsv
+	case "Step":
+		cm.Discrete = true
+		stepXs := []float64{xs[0]}
+		stepColors := []Color{colors[0]}
+		for i := 1; i < len(xs); i++ {
+			m := (xs[i-1] + xs[i]) / 2
+			stepXs = append(stepXs, m, m)
+			stepColors = append(stepColors, colors[i-1], colors[i])
+		}
+		xs = append(stepXs, xs[len(xs)-1])
+		colors = append(stepColors, colors[len(colors)-1])
+	default:
+		return cm, fmt.Errorf("invalid ParaView colormap %q: unsupported color space %q", name, space)
+	}
+
+	if len(xs) == 1 || xs[len(xs)-1]-xs[0] < epsilon {
+		xs = []float64{xs[0], xs[0] + 1}
+		colors = []Color{colors[0], colors[0]}
+	}
+
+	grad, err := NewGradient().
+		Colors(colors...).
+		Domain(xs...).
+		Mode(mode).
+		Build()
+	if err != nil {
+		return cm, fmt.Errorf("invalid ParaView colormap %q: %v", name, err)
+	}
+	cm.Gradient = grad
+	return cm, nil
+}
+
+// Color space and points of a colormap. Linear gradients blended in Lab are
+// written exactly in the Lab color space, the others as in WriteCpt.
+func paraviewPoints(cm Colormap) (string, []Color, []float64) {
+	if core, ok := cm.Gradient.Core.(linearGradient); ok && core.mode == BlendLab {
+		colors := make([]Color, len(core.colors))
+		for i, c := range core.colors {
+			colors[i] = colorFromSpace(BlendLab, c[0], c[1], c[2], c[3])
+		}
+		return "Lab", colors, core.positions
+	}
+	colors, positions := segmentStops(colormapSegments(cm.Gradient))
+	return "RGB", colors, positions
+}
+
+// WriteParaViewXml writes the colormaps as a ParaView XML colormap file.
+// Linear gradients blended in Lab use the Lab color space, the others are
+// written in RGB, exactly or approximated as in WriteCpt. Only the NaN color
+// is written, as the XML format has no background and foreground colors.
+func WriteParaViewXml(w io.Writer, colormaps []Colormap) error {
+	file := paraviewXmlFile{}
+	for _, cm := range colormaps {
+		space, colors, positions := paraviewPoints(cm)
+		m := paraviewXmlMap{Name: cm.Name, Space: space}
+		for i, c := range colors {
+			m.Points = append(m.Points, paraviewXmlPoint{positions[i], 1, paraviewXmlColor{c.R, c.G, c.B}})
+		}
+		if _, _, nan := cm.colors(); nan != nil {
+			c := nan.Clamp()
+			m.NaN = &paraviewXmlColor{c.R, c.G, c.B}
+		}
+		file.Maps = append(file.Maps, m)
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := xml.NewEncoder(bw)
+	enc.Indent("", "  ")
+	if err := enc.Encode(file); err != nil {
+		return err
+	}
+	bw.WriteByte('\n')
+	return bw.Flush()
+}
+
+// WriteParaViewJson writes the colormaps as a ParaView JSON colormap file,
+// with the same color spaces as WriteParaViewXml.
+func WriteParaViewJson(w io.Writer, colormaps []Colormap) error {
+	maps := make([]paraviewJsonMap, len(colormaps))
+	for i, cm := range colormaps {
+		space, colors, positions := paraviewPoints(cm)
+		m := paraviewJsonMap{Name: cm.Name, ColorSpace: space, RGBPoints: []float64{}}
+		for j, c := range colors {
+			m.RGBPoints = append(m.RGBPoints, positions[j], c.R, c.G, c.B)
+		}
+		use := true
+		bg, fg, nan := cm.colors()
+		if nan != nil {
+			m.NanColor = paraviewRgb(*nan)
+		}
+		if bg != nil {
+			m.BelowRangeColor = paraviewRgb(*bg)
+			m.UseBelowRangeColor = &use
+		}
+		if fg != nil {
+			m.AboveRangeColor = paraviewRgb(*fg)
+			m.UseAboveRangeColor = &use
+		}
+		maps[i] = m
+	}
+
+	data, err := json.MarshalIndent(maps, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+func paraviewRgb(c Color) []float64 {
+	c = c.Clamp()
+	return []float64{c.R, c.G, c.B}
+}
@@ -0,0 +1,143 @@
+package colorgrad
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_ParaViewXml(t *testing.T) {
+	xml := `<ColorMaps>
+<ColorMap name="Blue Red" space="RGB">
+  <Point x="-1" o="1" r="0" g="0" b="1"/>
+  <Point x="1" o="1" r="1" g="0" b="0"/>
+  <NaN r="1" g="1" b="0"/>
+</ColorMap>
+<ColorMap name="Steps" space="Step">
+  <Point x="0" o="1" r="0" g="0" b="0"/>
+  <Point x="10" o="1" r="1" g="1" b="1"/>
+</ColorMap>
+</ColorMaps>`
+
+	cms, err := ParseParaViewXml(strings.NewReader(xml))
+	test(t, err, nil)
+	test(t, len(cms), 2)
+	test(t, cms[0].Name, "Blue Red")
+	test(t, cms[0].Gradient.Min, -1.0)
+	test(t, cms[0].Gradient.Max, 1.0)
+	test(t, cms[0].Gradient.At(0).HexString(), "#800080")
+	test(t, cms[0].NaN.HexString(), "#ffff00")
+	test(t, cms[1].Discrete, true)
+	test(t, cms[1].Gradient.At(4.9).HexString(), "#000000")
+	test(t, cms[1].Gradient.At(5.1).HexString(), "#ffffff")
+
+	var buf bytes.Buffer
+	err = WriteParaViewXml(&buf, cms[:1])
+	test(t, err, nil)
+	test(t, buf.String(), `<ColorMaps>
+  <ColorMap name="Blue Red" space="RGB">
+    <Point x="-1" o="1" r="0" g="0" b="1"></Point>
+    <Point x="1" o="1" r="1" g="0" b="0"></Point>
+    <NaN r="1" g="1" b="0"></NaN>
+  </ColorMap>
+</ColorMaps>
+`)
+
+	// Single colormap, Lab
+	grad, _ := NewGradient().HtmlColors("gold", "navy").Domain(0, 100).Mode(BlendLab).Build()
+	buf.Reset()
+	err = WriteParaViewXml(&buf, []Colormap{{Name: "lab", Gradient: grad}})
+	test(t, err, nil)
+	testTrue(t, strings.Contains(buf.String(), `space="Lab"`))
+	cms, err = ParseParaViewXml(strings.NewReader(strings.TrimPrefix(strings.TrimSuffix(strings.TrimSpace(buf.String()), "</ColorMaps>"), "<ColorMaps>")))
+	test(t, err, nil)
+	test(t, len(cms), 1)
+	for _, x := range []float64{0, 25, 50, 100} {
+		test(t, cms[0].Gradient.At(x).HexString(), grad.At(x).HexString())
+	}
+
+	// Invalid
+	data := []string{
+		"",
+		"<ColorMaps></ColorMaps>",
+		`<ColorMap space="XYZ"><Point x="0" r="0" g="0" b="0"/></ColorMap>`,
+		`<ColorMap><Point x="1" r="0" g="0" b="0"/><Point x="0" r="0" g="0" b="0"/></ColorMap>`,
+		`<ColorMap></ColorMap>`,
+	}
+	for _, s := range data {
+		_, err := ParseParaViewXml(strings.NewReader(s))
+		testTrue(t, err != nil)
+	}
+}
+
+func Test_ParaViewJson(t *testing.T) {
+	js := `[{
+  "Name": "Cool",
+  "ColorSpace": "Diverging",
+  "NanColor": [1, 1, 0],
+  "AboveRangeColor": [1, 1, 1],
+  "UseAboveRangeColor": true,
+  "BelowRangeColor": [0, 0, 0],
+  "UseBelowRangeColor": false,
+  "RGBPoints": [0, 0, 0, 1, 50, 1, 1, 1, 100, 1, 0, 0]
+}]`
+
+	cms, err := ParseParaViewJson(strings.NewReader(js))
+	test(t, err, nil)
+	test(t, len(cms), 1)
+	cm := cms[0]
+	test(t, cm.Name, "Cool")
+	test(t, cm.Gradient.Min, 0.0)
+	test(t, cm.Gradient.Max, 100.0)
+	test(t, cm.Gradient.At(50).HexString(), "#ffffff")
+	test(t, cm.NaN.HexString(), "#ffff00")
+	test(t, cm.Foreground.HexString(), "#ffffff")
+	test(t, cm.Background, (*Color)(nil))
+
+	grad, _ := NewGradient().HtmlColors("red", "blue").Domain(-5, 5).Build()
+	bg := Rgb(0, 1, 0, 1)
+	var buf bytes.Buffer
+	err = WriteParaViewJson(&buf, []Colormap{{Name: "rb", Gradient: grad, Background: &bg}})
+	test(t, err, nil)
+	test(t, buf.String(), `[
+  {
+    "Name": "rb",
+    "ColorSpace": "RGB",
+    "BelowRangeColor": [
+      0,
+      1,
+      0
+    ],
+    "UseBelowRangeColor": true,
+    "RGBPoints": [
+      -5,
+      1,
+      0,
+      0,
+      5,
+      0,
+      0,
+      1
+    ]
+  }
+]
+`)
+
+	// Single object
+	cms, err = ParseParaViewJson(strings.NewReader(`{"RGBPoints": [2, 1, 0, 0]}`))
+	test(t, err, nil)
+	test(t, cms[0].Gradient.At(2).HexString(), "#ff0000")
+
+	// Invalid
+	data := []string{
+		"",
+		"[]",
+		`{"RGBPoints": [0, 1, 0]}`,
+		`{"RGBPoints": []}`,
+		`{"RGBPoints": [0, 1, 0, 0], "NanColor": [1]}`,
+	}
+	for _, s := range data {
+		_, err := ParseParaViewJson(strings.NewReader(s))
+		testTrue(t, err != nil)
+	}
+}
@@ -19,6 +19,7 @@ func Sinebow() Gradient {
 		Core: sinebowGradient{},
 		Min:  0,
 		Max:  1,
+		spec: &GradientSpec{Preset: "Sinebow"},
 	}
 }
 
@@ -41,6 +42,7 @@ func Turbo() Gradient {
 		Core: turboGradient{},
 		Min:  0,
 		Max:  1,
+		spec: &GradientSpec{Preset: "Turbo"},
 	}
 }
 
@@ -66,6 +68,7 @@ func Cividis() Gradient {
 		Core: cividisGradient{},
 		Min:  0,
 		Max:  1,
+		spec: &GradientSpec{Preset: "Cividis"},
 	}
 }
 
@@ -128,6 +131,7 @@ func CubehelixDefault() Gradient {
 		Core: gradbase,
 		Min:  0,
 		Max:  1,
+		spec: &GradientSpec{Preset: "CubehelixDefault"},
 	}
 }
 
@@ -140,6 +144,7 @@ func Warm() Gradient {
 		Core: gradbase,
 		Min:  0,
 		Max:  1,
+		spec: &GradientSpec{Preset: "Warm"},
 	}
 }
 
@@ -152,6 +157,7 @@ func Cool() Gradient {
 		Core: gradbase,
 		Min:  0,
 		Max:  1,
+		spec: &GradientSpec{Preset: "Cool"},
 	}
 }
 
@@ -168,6 +174,7 @@ func Rainbow() Gradient {
 		Core: rainbowGradient{},
 		Min:  0,
 		Max:  1,
+		spec: &GradientSpec{Preset: "Rainbow"},
 	}
 }
 
@@ -190,172 +197,617 @@ func u32ToColor(v uint32) Color {
 	return Rgb8(r, g, b, 255)
 }
 
-func preset(data []uint32) Gradient {
+func preset(name string, data []uint32) Gradient {
 	colors := make([]Color, len(data))
 	for i, v := range data {
 		colors[i] = u32ToColor(v)
 	}
 	pos := linspace(0, 1, uint(len(colors)))
-	return newBasisGradient(colors, pos, BlendRgb)
+	grad := newBasisGradient(colors, pos, BlendRgb, HueShorter)
+	grad.spec = &GradientSpec{Preset: name}
+	return grad
 }
 
 // Diverging
 
 func BrBG() Gradient {
 	colors := []uint32{0x543005, 0x8c510a, 0xbf812d, 0xdfc27d, 0xf6e8c3, 0xf5f5f5, 0xc7eae5, 0x80cdc1, 0x35978f, 0x01665e, 0x003c30}
-	return preset(colors)
+	return preset("BrBG", colors)
 }
 
 func PRGn() Gradient {
 	colors := []uint32{0x40004b, 0x762a83, 0x9970ab, 0xc2a5cf, 0xe7d4e8, 0xf7f7f7, 0xd9f0d3, 0xa6dba0, 0x5aae61, 0x1b7837, 0x00441b}
-	return preset(colors)
+	return preset("PRGn", colors)
 }
 
 func PiYG() Gradient {
 	colors := []uint32{0x8e0152, 0xc51b7d, 0xde77ae, 0xf1b6da, 0xfde0ef, 0xf7f7f7, 0xe6f5d0, 0xb8e186, 0x7fbc41, 0x4d9221, 0x276419}
-	return preset(colors)
+	return preset("PiYG", colors)
 }
 
 func PuOr() Gradient {
 	colors := []uint32{0x2d004b, 0x542788, 0x8073ac, 0xb2abd2, 0xd8daeb, 0xf7f7f7, 0xfee0b6, 0xfdb863, 0xe08214, 0xb35806, 0x7f3b08}
-	return preset(colors)
+	return preset("PuOr", colors)
 }
 
 func RdBu() Gradient {
 	colors := []uint32{0x67001f, 0xb2182b, 0xd6604d, 0xf4a582, 0xfddbc7, 0xf7f7f7, 0xd1e5f0, 0x92c5de, 0x4393c3, 0x2166ac, 0x053061}
-	return preset(colors)
+	return preset("RdBu", colors)
 }
 
 func RdGy() Gradient {
 	colors := []uint32{0x67001f, 0xb2182b, 0xd6604d, 0xf4a582, 0xfddbc7, 0xffffff, 0xe0e0e0, 0xbababa, 0x878787, 0x4d4d4d, 0x1a1a1a}
-	return preset(colors)
+	return preset("RdGy", colors)
 }
 
 func RdYlBu() Gradient {
 	colors := []uint32{0xa50026, 0xd73027, 0xf46d43, 0xfdae61, 0xfee090, 0xffffbf, 0xe0f3f8, 0xabd9e9, 0x74add1, 0x4575b4, 0x313695}
-	return preset(colors)
+	return preset("RdYlBu", colors)
 }
 
 func RdYlGn() Gradient {
 	colors := []uint32{0xa50026, 0xd73027, 0xf46d43, 0xfdae61, 0xfee08b, 0xffffbf, 0xd9ef8b, 0xa6d96a, 0x66bd63, 0x1a9850, 0x006837}
-	return preset(colors)
+	return preset("RdYlGn", colors)
 }
 
 func Spectral() Gradient {
 	colors := []uint32{0x9e0142, 0xd53e4f, 0xf46d43, 0xfdae61, 0xfee08b, 0xffffbf, 0xe6f598, 0xabdda4, 0x66c2a5, 0x3288bd, 0x5e4fa2}
-	return preset(colors)
+	return preset("Spectral", colors)
 }
 
 // Sequential (Single Hue)
 
 func Blues() Gradient {
 	colors := []uint32{0xf7fbff, 0xdeebf7, 0xc6dbef, 0x9ecae1, 0x6baed6, 0x4292c6, 0x2171b5, 0x08519c, 0x08306b}
-	return preset(colors)
+	return preset("Blues", colors)
 }
 
 func Greens() Gradient {
 	colors := []uint32{0xf7fcf5, 0xe5f5e0, 0xc7e9c0, 0xa1d99b, 0x74c476, 0x41ab5d, 0x238b45, 0x006d2c, 0x00441b}
-	return preset(colors)
+	return preset("Greens", colors)
 }
 
 func Greys() Gradient {
 	colors := []uint32{0xffffff, 0xf0f0f0, 0xd9d9d9, 0xbdbdbd, 0x969696, 0x737373, 0x525252, 0x252525, 0x000000}
-	return preset(colors)
+	return preset("Greys", colors)
 }
 
 func Oranges() Gradient {
 	colors := []uint32{0xfff5eb, 0xfee6ce, 0xfdd0a2, 0xfdae6b, 0xfd8d3c, 0xf16913, 0xd94801, 0xa63603, 0x7f2704}
-	return preset(colors)
+	return preset("Oranges", colors)
 }
 
 func Purples() Gradient {
 	colors := []uint32{0xfcfbfd, 0xefedf5, 0xdadaeb, 0xbcbddc, 0x9e9ac8, 0x807dba, 0x6a51a3, 0x54278f, 0x3f007d}
-	return preset(colors)
+	return preset("Purples", colors)
 }
 
 func Reds() Gradient {
 	colors := []uint32{0xfff5f0, 0xfee0d2, 0xfcbba1, 0xfc9272, 0xfb6a4a, 0xef3b2c, 0xcb181d, 0xa50f15, 0x67000d}
-	return preset(colors)
+	return preset("Reds", colors)
 }
 
 // Sequential (Multi-Hue)
 
 func Viridis() Gradient {
 	colors := []uint32{0x440154, 0x482777, 0x3f4a8a, 0x31678e, 0x26838f, 0x1f9d8a, 0x6cce5a, 0xb6de2b, 0xfee825}
-	return preset(colors)
+	return preset("Viridis", colors)
 }
 
 func Inferno() Gradient {
 	colors := []uint32{0x000004, 0x170b3a, 0x420a68, 0x6b176e, 0x932667, 0xbb3654, 0xdd513a, 0xf3771a, 0xfca50a, 0xf6d644, 0xfcffa4}
-	return preset(colors)
+	return preset("Inferno", colors)
 }
 
 func Magma() Gradient {
 	colors := []uint32{0x000004, 0x140e37, 0x3b0f70, 0x641a80, 0x8c2981, 0xb63679, 0xde4968, 0xf66f5c, 0xfe9f6d, 0xfece91, 0xfcfdbf}
-	return preset(colors)
+	return preset("Magma", colors)
 }
 
 func Plasma() Gradient {
 	colors := []uint32{0x0d0887, 0x42039d, 0x6a00a8, 0x900da3, 0xb12a90, 0xcb4678, 0xe16462, 0xf1834b, 0xfca636, 0xfccd25, 0xf0f921}
-	return preset(colors)
+	return preset("Plasma", colors)
 }
 
 func BuGn() Gradient {
 	colors := []uint32{0xf7fcfd, 0xe5f5f9, 0xccece6, 0x99d8c9, 0x66c2a4, 0x41ae76, 0x238b45, 0x006d2c, 0x00441b}
-	return preset(colors)
+	return preset("BuGn", colors)
 }
 
 func BuPu() Gradient {
 	colors := []uint32{0xf7fcfd, 0xe0ecf4, 0xbfd3e6, 0x9ebcda, 0x8c96c6, 0x8c6bb1, 0x88419d, 0x810f7c, 0x4d004b}
-	return preset(colors)
+	return preset("BuPu", colors)
 }
 
 func GnBu() Gradient {
 	colors := []uint32{0xf7fcf0, 0xe0f3db, 0xccebc5, 0xa8ddb5, 0x7bccc4, 0x4eb3d3, 0x2b8cbe, 0x0868ac, 0x084081}
-	return preset(colors)
+	return preset("GnBu", colors)
 }
 
 func OrRd() Gradient {
 	colors := []uint32{0xfff7ec, 0xfee8c8, 0xfdd49e, 0xfdbb84, 0xfc8d59, 0xef6548, 0xd7301f, 0xb30000, 0x7f0000}
-	return preset(colors)
+	return preset("OrRd", colors)
 }
 
 func PuBuGn() Gradient {
 	colors := []uint32{0xfff7fb, 0xece2f0, 0xd0d1e6, 0xa6bddb, 0x67a9cf, 0x3690c0, 0x02818a, 0x016c59, 0x014636}
-	return preset(colors)
+	return preset("PuBuGn", colors)
 }
 
 func PuBu() Gradient {
 	colors := []uint32{0xfff7fb, 0xece7f2, 0xd0d1e6, 0xa6bddb, 0x74a9cf, 0x3690c0, 0x0570b0, 0x045a8d, 0x023858}
-	return preset(colors)
+	return preset("PuBu", colors)
 }
 
 func PuRd() Gradient {
 	colors := []uint32{0xf7f4f9, 0xe7e1ef, 0xd4b9da, 0xc994c7, 0xdf65b0, 0xe7298a, 0xce1256, 0x980043, 0x67001f}
-	return preset(colors)
+	return preset("PuRd", colors)
 }
 
 func RdPu() Gradient {
 	colors := []uint32{0xfff7f3, 0xfde0dd, 0xfcc5c0, 0xfa9fb5, 0xf768a1, 0xdd3497, 0xae017e, 0x7a0177, 0x49006a}
-	return preset(colors)
+	return preset("RdPu", colors)
 }
 
 func YlGnBu() Gradient {
 	colors := []uint32{0xffffd9, 0xedf8b1, 0xc7e9b4, 0x7fcdbb, 0x41b6c4, 0x1d91c0, 0x225ea8, 0x253494, 0x081d58}
-	return preset(colors)
+	return preset("YlGnBu", colors)
 }
 
 func YlGn() Gradient {
 	colors := []uint32{0xffffe5, 0xf7fcb9, 0xd9f0a3, 0xaddd8e, 0x78c679, 0x41ab5d, 0x238443, 0x006837, 0x004529}
-	return preset(colors)
+	return preset("YlGn", colors)
 }
 
 func YlOrBr() Gradient {
 	colors := []uint32{0xffffe5, 0xfff7bc, 0xfee391, 0xfec44f, 0xfe9929, 0xec7014, 0xcc4c02, 0x993404, 0x662506}
-	return preset(colors)
+	return preset("YlOrBr", colors)
 }
 
 func YlOrRd() Gradient {
 	colors := []uint32{0xffffcc, 0xffeda0, 0xfed976, 0xfeb24c, 0xfd8d3c, 0xfc4e2a, 0xe31a1c, 0xbd0026, 0x800026}
-	return preset(colors)
+	return preset("YlOrRd", colors)
+}
+
+// --- matplotlib presets, the 256 colors of the colormap (2 for those linear in
+// every channel) sampled from the segments and functions of matplotlib
+// Reference: https://github.com/matplotlib/matplotlib/blob/main/lib/matplotlib/_cm.py
+
+func Jet() Gradient {
+	colors := []uint32{
+		0x000080, 0x000084, 0x000089, 0x00008d, 0x000092, 0x000096, 0x00009b, 0x00009f,
+		0x0000a4, 0x0000a8, 0x0000ad, 0x0000b2, 0x0000b6, 0x0000bb, 0x0000bf, 0x0000c4,
+		0x0000c8, 0x0000cd, 0x0000d1, 0x0000d6, 0x0000da, 0x0000df, 0x0000e3, 0x0000e8,
+		0x0000ed, 0x0000f1, 0x0000f6, 0x0000fa, 0x0000ff, 0x0000ff, 0x0000ff, 0x0000ff,
+		0x0000ff, 0x0005ff, 0x0008ff, 0x000dff, 0x0010ff, 0x0015ff, 0x0018ff, 0x001dff,
+		0x0021ff, 0x0025ff, 0x0029ff, 0x002dff, 0x0031ff, 0x0035ff, 0x0039ff, 0x003dff,
+		0x0041ff, 0x0045ff, 0x0049ff, 0x004dff, 0x0051ff, 0x0055ff, 0x0059ff, 0x005dff,
+		0x0061ff, 0x0065ff, 0x0069ff, 0x006dff, 0x0071ff, 0x0075ff, 0x0079ff, 0x007dff,
+		0x0081ff, 0x0084ff, 0x0089ff, 0x008dff, 0x0091ff, 0x0094ff, 0x0099ff, 0x009dff,
+		0x00a1ff, 0x00a4ff, 0x00a9ff, 0x00adff, 0x00b1ff, 0x00b4ff, 0x00b9ff, 0x00bdff,
+		0x00c1ff, 0x00c4ff, 0x00c9ff, 0x00cdff, 0x00d1ff, 0x00d4ff, 0x00d9ff, 0x00ddfe,
+		0x00e1fb, 0x00e4f8, 0x02e9f4, 0x06edf1, 0x09f1ee, 0x0cf4eb, 0x0ff9e7, 0x13fde4,
+		0x16ffe1, 0x19ffde, 0x1cffdb, 0x1fffd7, 0x23ffd4, 0x26ffd1, 0x29ffce, 0x2cffca,
+		0x30ffc7, 0x33ffc4, 0x36ffc1, 0x39ffbe, 0x3cffba, 0x40ffb7, 0x43ffb4, 0x46ffb1,
+		0x49ffad, 0x4dffaa, 0x50ffa7, 0x53ffa4, 0x56ffa0, 0x5aff9d, 0x5dff9a, 0x60ff97,
+		0x63ff94, 0x66ff90, 0x6aff8d, 0x6dff8a, 0x70ff87, 0x73ff83, 0x77ff80, 0x7aff7d,
+		0x7dff7a, 0x80ff77, 0x83ff73, 0x87ff70, 0x8aff6d, 0x8dff6a, 0x90ff66, 0x94ff63,
+		0x97ff60, 0x9aff5d, 0x9dff5a, 0xa0ff56, 0xa4ff53, 0xa7ff50, 0xaaff4d, 0xadff49,
+		0xb1ff46, 0xb4ff43, 0xb7ff40, 0xbaff3c, 0xbeff39, 0xc1ff36, 0xc4ff33, 0xc7ff30,
+		0xcaff2c, 0xceff29, 0xd1ff26, 0xd4ff23, 0xd7ff1f, 0xdbff1c, 0xdeff19, 0xe1ff16,
+		0xe4ff13, 0xe7ff0f, 0xebff0c, 0xeeff09, 0xf1fc06, 0xf4f802, 0xf8f500, 0xfbf100,
+		0xfeed00, 0xffea00, 0xffe600, 0xffe200, 0xffde00, 0xffdb00, 0xffd700, 0xffd300,
+		0xffd000, 0xffcc00, 0xffc800, 0xffc400, 0xffc100, 0xffbd00, 0xffb900, 0xffb600,
+		0xffb200, 0xffae00, 0xffab00, 0xffa700, 0xffa300, 0xff9f00, 0xff9c00, 0xff9800,
+		0xff9400, 0xff9100, 0xff8d00, 0xff8900, 0xff8600, 0xff8200, 0xff7e00, 0xff7a00,
+		0xff7700, 0xff7300, 0xff6f00, 0xff6c00, 0xff6800, 0xff6400, 0xff6000, 0xff5d00,
+		0xff5900, 0xff5500, 0xff5200, 0xff4e00, 0xff4a00, 0xff4700, 0xff4300, 0xff3f00,
+		0xff3b00, 0xff3800, 0xff3400, 0xff3000, 0xff2d00, 0xff2900, 0xff2500, 0xff2200,
+		0xff1e00, 0xff1a00, 0xff1600, 0xff1300, 0xfa0f00, 0xf60b00, 0xf10800, 0xed0400,
+		0xe80000, 0xe40000, 0xdf0000, 0xda0000, 0xd60000, 0xd10000, 0xcd0000, 0xc80000,
+		0xc40000, 0xbf0000, 0xbb0000, 0xb60000, 0xb10000, 0xad0000, 0xa80000, 0xa40000,
+		0x9f0000, 0x9b0000, 0x960000, 0x920000, 0x8d0000, 0x890000, 0x840000, 0x800000,
+	}
+	return preset("Jet", colors)
+}
+
+func Hot() Gradient {
+	colors := []uint32{
+		0x0b0000, 0x0d0000, 0x100000, 0x120000, 0x150000, 0x180000, 0x1a0000, 0x1d0000,
+		0x200000, 0x220000, 0x250000, 0x270000, 0x2a0000, 0x2d0000, 0x2f0000, 0x320000,
+		0x350000, 0x370000, 0x3a0000, 0x3c0000, 0x3f0000, 0x420000, 0x440000, 0x470000,
+		0x4a0000, 0x4c0000, 0x4f0000, 0x510000, 0x540000, 0x570000, 0x590000, 0x5c0000,
+		0x5f0000, 0x610000, 0x640000, 0x660000, 0x690000, 0x6c0000, 0x6e0000, 0x710000,
+		0x740000, 0x760000, 0x790000, 0x7b0000, 0x7e0000, 0x810000, 0x830000, 0x860000,
+		0x890000, 0x8b0000, 0x8e0000, 0x900000, 0x930000, 0x960000, 0x980000, 0x9b0000,
+		0x9e0000, 0xa00000, 0xa30000, 0xa50000, 0xa80000, 0xab0000, 0xad0000, 0xb00000,
+		0xb30000, 0xb50000, 0xb80000, 0xba0000, 0xbd0000, 0xc00000, 0xc20000, 0xc50000,
+		0xc80000, 0xca0000, 0xcd0000, 0xcf0000, 0xd20000, 0xd50000, 0xd70000, 0xda0000,
+		0xdd0000, 0xdf0000, 0xe20000, 0xe40000, 0xe70000, 0xea0000, 0xec0000, 0xef0000,
+		0xf20000, 0xf40000, 0xf70000, 0xf90000, 0xfc0000, 0xff0000, 0xff0200, 0xff0500,
+		0xff0800, 0xff0a00, 0xff0d00, 0xff1000, 0xff1200, 0xff1500, 0xff1700, 0xff1a00,
+		0xff1d00, 0xff1f00, 0xff2200, 0xff2500, 0xff2700, 0xff2a00, 0xff2c00, 0xff2f00,
+		0xff3200, 0xff3400, 0xff3700, 0xff3a00, 0xff3c00, 0xff3f00, 0xff4100, 0xff4400,
+		0xff4700, 0xff4900, 0xff4c00, 0xff4f00, 0xff5100, 0xff5400, 0xff5600, 0xff5900,
+		0xff5c00, 0xff5e00, 0xff6100, 0xff6400, 0xff6600, 0xff6900, 0xff6b00, 0xff6e00,
+		0xff7100, 0xff7300, 0xff7600, 0xff7900, 0xff7b00, 0xff7e00, 0xff8000, 0xff8300,
+		0xff8600, 0xff8800, 0xff8b00, 0xff8e00, 0xff9000, 0xff9300, 0xff9500, 0xff9800,
+		0xff9b00, 0xff9d00, 0xffa000, 0xffa200, 0xffa500, 0xffa800, 0xffaa00, 0xffad00,
+		0xffb000, 0xffb200, 0xffb500, 0xffb700, 0xffba00, 0xffbd00, 0xffbf00, 0xffc200,
+		0xffc500, 0xffc700, 0xffca00, 0xffcc00, 0xffcf00, 0xffd200, 0xffd400, 0xffd700,
+		0xffda00, 0xffdc00, 0xffdf00, 0xffe100, 0xffe400, 0xffe700, 0xffe900, 0xffec00,
+		0xffef00, 0xfff100, 0xfff400, 0xfff600, 0xfff900, 0xfffc00, 0xfffe00, 0xffff03,
+		0xffff07, 0xffff0b, 0xffff0f, 0xffff13, 0xffff17, 0xffff1b, 0xffff1f, 0xffff22,
+		0xffff26, 0xffff2a, 0xffff2e, 0xffff32, 0xffff36, 0xffff3a, 0xffff3e, 0xffff42,
+		0xffff46, 0xffff4a, 0xffff4e, 0xffff52, 0xffff56, 0xffff5a, 0xffff5e, 0xffff61,
+		0xffff65, 0xffff69, 0xffff6d, 0xffff71, 0xffff75, 0xffff79, 0xffff7d, 0xffff81,
+		0xffff85, 0xffff89, 0xffff8d, 0xffff91, 0xffff95, 0xffff99, 0xffff9d, 0xffffa0,
+		0xffffa4, 0xffffa8, 0xffffac, 0xffffb0, 0xffffb4, 0xffffb8, 0xffffbc, 0xffffc0,
+		0xffffc4, 0xffffc8, 0xffffcc, 0xffffd0, 0xffffd4, 0xffffd8, 0xffffdc, 0xffffdf,
+		0xffffe3, 0xffffe7, 0xffffeb, 0xffffef, 0xfffff3, 0xfffff7, 0xfffffb, 0xffffff,
+	}
+	return preset("Hot", colors)
+}
+
+func Afmhot() Gradient {
+	colors := []uint32{
+		0x000000, 0x020000, 0x040000, 0x060000, 0x080000, 0x0a0000, 0x0c0000, 0x0e0000,
+		0x100000, 0x120000, 0x140000, 0x160000, 0x180000, 0x1a0000, 0x1c0000, 0x1e0000,
+		0x200000, 0x220000, 0x240000, 0x260000, 0x280000, 0x2a0000, 0x2c0000, 0x2e0000,
+		0x300000, 0x320000, 0x340000, 0x360000, 0x380000, 0x3a0000, 0x3c0000, 0x3e0000,
+		0x400000, 0x420000, 0x440000, 0x460000, 0x480000, 0x4a0000, 0x4c0000, 0x4e0000,
+		0x500000, 0x520000, 0x540000, 0x560000, 0x580000, 0x5a0000, 0x5c0000, 0x5e0000,
+		0x600000, 0x620000, 0x640000, 0x660000, 0x680000, 0x6a0000, 0x6c0000, 0x6e0000,
+		0x700000, 0x720000, 0x740000, 0x760000, 0x780000, 0x7a0000, 0x7c0000, 0x7e0000,
+		0x800000, 0x820200, 0x840500, 0x860700, 0x880800, 0x8a0a00, 0x8c0d00, 0x8e0f00,
+		0x901000, 0x921200, 0x941500, 0x961700, 0x981800, 0x9a1a00, 0x9c1d00, 0x9e1f00,
+		0xa02100, 0xa22200, 0xa42500, 0xa62700, 0xa82900, 0xaa2a00, 0xac2d00, 0xae2f00,
+		0xb03100, 0xb23200, 0xb43500, 0xb63700, 0xb83900, 0xba3a00, 0xbc3d00, 0xbe3f00,
+		0xc04100, 0xc24200, 0xc44500, 0xc64700, 0xc84900, 0xca4a00, 0xcc4d00, 0xce4f00,
+		0xd05100, 0xd25200, 0xd45500, 0xd65700, 0xd85900, 0xda5a00, 0xdc5d00, 0xde5f00,
+		0xe06100, 0xe26200, 0xe46500, 0xe66700, 0xe86900, 0xea6a00, 0xec6d00, 0xee6f00,
+		0xf07100, 0xf27200, 0xf47500, 0xf67700, 0xf87900, 0xfa7a00, 0xfc7d00, 0xfe7f00,
+		0xff8101, 0xff8303, 0xff8405, 0xff8607, 0xff8909, 0xff8b0b, 0xff8d0d, 0xff8f0f,
+		0xff9111, 0xff9313, 0xff9415, 0xff9617, 0xff9919, 0xff9b1b, 0xff9d1d, 0xff9f1f,
+		0xffa121, 0xffa323, 0xffa425, 0xffa627, 0xffa929, 0xffab2b, 0xffad2d, 0xffaf2f,
+		0xffb131, 0xffb333, 0xffb435, 0xffb637, 0xffb939, 0xffbb3b, 0xffbd3d, 0xffbf3f,
+		0xffc141, 0xffc343, 0xffc445, 0xffc647, 0xffc949, 0xffcb4b, 0xffcd4d, 0xffcf4f,
+		0xffd151, 0xffd353, 0xffd455, 0xffd657, 0xffd959, 0xffdb5b, 0xffdd5d, 0xffdf5f,
+		0xffe161, 0xffe363, 0xffe465, 0xffe667, 0xffe969, 0xffeb6b, 0xffed6d, 0xffef6f,
+		0xfff171, 0xfff373, 0xfff475, 0xfff677, 0xfff979, 0xfffb7b, 0xfffd7d, 0xffff7f,
+		0xffff81, 0xffff83, 0xffff85, 0xffff87, 0xffff89, 0xffff8b, 0xffff8d, 0xffff8f,
+		0xffff91, 0xffff93, 0xffff95, 0xffff97, 0xffff99, 0xffff9b, 0xffff9d, 0xffff9f,
+		0xffffa1, 0xffffa3, 0xffffa5, 0xffffa7, 0xffffa9, 0xffffab, 0xffffad, 0xffffaf,
+		0xffffb1, 0xffffb3, 0xffffb5, 0xffffb7, 0xffffb9, 0xffffbb, 0xffffbd, 0xffffbf,
+		0xffffc1, 0xffffc3, 0xffffc5, 0xffffc7, 0xffffc9, 0xffffcb, 0xffffcd, 0xffffcf,
+		0xffffd1, 0xffffd3, 0xffffd5, 0xffffd7, 0xffffd9, 0xffffdb, 0xffffdd, 0xffffdf,
+		0xffffe1, 0xffffe3, 0xffffe5, 0xffffe7, 0xffffe9, 0xffffeb, 0xffffed, 0xffffef,
+		0xfffff1, 0xfffff3, 0xfffff5, 0xfffff7, 0xfffff9, 0xfffffb, 0xfffffd, 0xffffff,
+	}
+	return preset("Afmhot", colors)
+}
+
+func Gnuplot() Gradient {
+	colors := []uint32{
+		0x000000, 0x100006, 0x17000d, 0x1c0013, 0x200019, 0x24001f, 0x270026, 0x2a002c,
+		0x2d0032, 0x300038, 0x32003e, 0x350044, 0x37004a, 0x3a0050, 0x3c0056, 0x3e005c,
+		0x400062, 0x420068, 0x44006d, 0x460073, 0x470079, 0x49007e, 0x4b0084, 0x4d0089,
+		0x4e008e, 0x500093, 0x510098, 0x53009d, 0x5400a2, 0x5600a7, 0x5700ac, 0x5900b0,
+		0x5a01b5, 0x5c01b9, 0x5d01be, 0x5e01c2, 0x6001c6, 0x6101ca, 0x6201cd, 0x6401d1,
+		0x6501d5, 0x6601d8, 0x6701db, 0x6901de, 0x6a01e1, 0x6b01e4, 0x6c01e7, 0x6d02ea,
+		0x6f02ec, 0x7002ee, 0x7102f1, 0x7202f3, 0x7302f4, 0x7402f6, 0x7502f8, 0x7603f9,
+		0x7703fa, 0x7903fb, 0x7a03fc, 0x7b03fd, 0x7c03fe, 0x7d03fe, 0x7e04ff, 0x7f04ff,
+		0x8004ff, 0x8104ff, 0x8204ff, 0x8305fe, 0x8405fe, 0x8505fd, 0x8605fc, 0x8706fb,
+		0x8706fa, 0x8806f8, 0x8906f7, 0x8a06f5, 0x8b07f3, 0x8c07f2, 0x8d07ef, 0x8e08ed,
+		0x8f08eb, 0x9008e8, 0x9108e6, 0x9109e3, 0x9209e0, 0x9309dd, 0x940ada, 0x950ad6,
+		0x960ad3, 0x970bcf, 0x970bcb, 0x980cc8, 0x990cc4, 0x9a0cc0, 0x9b0dbb, 0x9c0db7,
+		0x9c0eb3, 0x9d0eae, 0x9e0ea9, 0x9f0fa5, 0xa00fa0, 0xa0109b, 0xa11096, 0xa21191,
+		0xa3118c, 0xa41286, 0xa41281, 0xa5137b, 0xa61376, 0xa71470, 0xa7146b, 0xa81565,
+		0xa9165f, 0xaa1659, 0xaa1753, 0xab174d, 0xac1847, 0xad1941, 0xad193b, 0xae1a35,
+		0xaf1b2f, 0xb01b29, 0xb01c22, 0xb11d1c, 0xb21d16, 0xb31e10, 0xb31f09, 0xb42003,
+		0xb52000, 0xb52100, 0xb62200, 0xb72300, 0xb72300, 0xb82400, 0xb92500, 0xba2600,
+		0xba2700, 0xbb2800, 0xbc2800, 0xbc2900, 0xbd2a00, 0xbe2b00, 0xbe2c00, 0xbf2d00,
+		0xc02e00, 0xc02f00, 0xc13000, 0xc23100, 0xc23200, 0xc33300, 0xc43400, 0xc43500,
+		0xc53600, 0xc63700, 0xc63800, 0xc73900, 0xc73a00, 0xc83c00, 0xc93d00, 0xc93e00,
+		0xca3f00, 0xcb4000, 0xcb4100, 0xcc4300, 0xcc4400, 0xcd4500, 0xce4600, 0xce4800,
+		0xcf4900, 0xd04a00, 0xd04c00, 0xd14d00, 0xd14e00, 0xd25000, 0xd35100, 0xd35200,
+		0xd45400, 0xd45500, 0xd55700, 0xd65800, 0xd65a00, 0xd75b00, 0xd75d00, 0xd85e00,
+		0xd96000, 0xd96100, 0xda6300, 0xda6500, 0xdb6600, 0xdc6800, 0xdc6900, 0xdd6b00,
+		0xdd6d00, 0xde6f00, 0xde7000, 0xdf7200, 0xe07400, 0xe07600, 0xe17700, 0xe17900,
+		0xe27b00, 0xe27d00, 0xe37f00, 0xe48100, 0xe48300, 0xe58400, 0xe58600, 0xe68800,
+		0xe68a00, 0xe78c00, 0xe78e00, 0xe89000, 0xe99300, 0xe99500, 0xea9700, 0xea9900,
+		0xeb9b00, 0xeb9d00, 0xec9f00, 0xeca200, 0xeda400, 0xeda600, 0xeea800, 0xeeab00,
+		0xefad00, 0xf0af00, 0xf0b200, 0xf1b400, 0xf1b600, 0xf2b900, 0xf2bb00, 0xf3be00,
+		0xf3c000, 0xf4c300, 0xf4c500, 0xf5c800, 0xf5ca00, 0xf6cd00, 0xf6cf00, 0xf7d200,
+		0xf7d500, 0xf8d700, 0xf8da00, 0xf9dd00, 0xf9df00, 0xfae200, 0xfae500, 0xfbe800,
+		0xfbeb00, 0xfced00, 0xfcf000, 0xfdf300, 0xfdf600, 0xfef900, 0xfefc00, 0xffff00,
+	}
+	return preset("Gnuplot", colors)
+}
+
+func Gnuplot2() Gradient {
+	colors := []uint32{
+		0x000000, 0x000004, 0x000008, 0x00000c, 0x000010, 0x000014, 0x000018, 0x00001c,
+		0x000020, 0x000024, 0x000028, 0x00002c, 0x000030, 0x000034, 0x000038, 0x00003c,
+		0x000040, 0x000044, 0x000048, 0x00004c, 0x000050, 0x000054, 0x000058, 0x00005c,
+		0x000060, 0x000064, 0x000068, 0x00006c, 0x000070, 0x000074, 0x000078, 0x00007c,
+		0x000080, 0x000084, 0x000088, 0x00008c, 0x000090, 0x000094, 0x000098, 0x00009c,
+		0x0000a0, 0x0000a4, 0x0000a8, 0x0000ac, 0x0000b0, 0x0000b4, 0x0000b8, 0x0000bc,
+		0x0000c0, 0x0000c4, 0x0000c8, 0x0000cc, 0x0000d0, 0x0000d4, 0x0000d8, 0x0000dc,
+		0x0000e0, 0x0000e4, 0x0000e8, 0x0000ec, 0x0000f0, 0x0000f4, 0x0000f8, 0x0000fc,
+		0x0100ff, 0x0400ff, 0x0700ff, 0x0a00ff, 0x0d00ff, 0x1000ff, 0x1400ff, 0x1700ff,
+		0x1a00ff, 0x1d00ff, 0x2000ff, 0x2300ff, 0x2600ff, 0x2900ff, 0x2d00ff, 0x3000ff,
+		0x3300ff, 0x3600ff, 0x3900ff, 0x3c00ff, 0x3f00ff, 0x4200ff, 0x4600ff, 0x4900ff,
+		0x4c00ff, 0x4f00ff, 0x5200ff, 0x5500ff, 0x5800ff, 0x5b00ff, 0x5f00ff, 0x6200ff,
+		0x6500ff, 0x6800ff, 0x6b00ff, 0x6e00ff, 0x7100ff, 0x7400ff, 0x7800ff, 0x7b00ff,
+		0x7e00ff, 0x8100ff, 0x8400ff, 0x8700ff, 0x8a02fd, 0x8d04fb, 0x9106f9, 0x9408f7,
+		0x970af5, 0x9a0cf3, 0x9d0ef1, 0xa010ef, 0xa312ed, 0xa614eb, 0xaa16e9, 0xad18e7,
+		0xb01ae5, 0xb31ce3, 0xb61ee1, 0xb920df, 0xbc22dd, 0xbf24db, 0xc326d9, 0xc628d7,
+		0xc92ad5, 0xcc2cd3, 0xcf2ed1, 0xd230cf, 0xd532cd, 0xd834cb, 0xdc36c9, 0xdf38c7,
+		0xe23ac5, 0xe53cc3, 0xe83ec1, 0xeb40bf, 0xee42bd, 0xf144bb, 0xf546b9, 0xf848b7,
+		0xfb4ab5, 0xfe4cb3, 0xff4eb1, 0xff50af, 0xff52ad, 0xff54ab, 0xff56a9, 0xff58a7,
+		0xff5aa5, 0xff5ca3, 0xff5ea1, 0xff609f, 0xff629d, 0xff649b, 0xff6699, 0xff6897,
+		0xff6a95, 0xff6c93, 0xff6e91, 0xff708f, 0xff728d, 0xff748b, 0xff7689, 0xff7887,
+		0xff7a85, 0xff7c83, 0xff7e81, 0xff807f, 0xff827d, 0xff847b, 0xff8679, 0xff8877,
+		0xff8a75, 0xff8c73, 0xff8e71, 0xff906f, 0xff926d, 0xff946b, 0xff9669, 0xff9867,
+		0xff9a65, 0xff9c63, 0xff9e61, 0xffa05f, 0xffa25d, 0xffa45b, 0xffa659, 0xffa857,
+		0xffaa55, 0xffac53, 0xffae51, 0xffb04f, 0xffb24d, 0xffb44b, 0xffb649, 0xffb847,
+		0xffba45, 0xffbc43, 0xffbe41, 0xffc03f, 0xffc23d, 0xffc43b, 0xffc639, 0xffc837,
+		0xffca35, 0xffcc33, 0xffce31, 0xffd02f, 0xffd22d, 0xffd42b, 0xffd629, 0xffd827,
+		0xffda25, 0xffdc23, 0xffde21, 0xffe01f, 0xffe21d, 0xffe41b, 0xffe619, 0xffe817,
+		0xffea15, 0xffec13, 0xffee11, 0xfff00f, 0xfff20d, 0xfff40b, 0xfff609, 0xfff807,
+		0xfffa05, 0xfffc03, 0xfffe01, 0xffff05, 0xffff11, 0xffff1e, 0xffff2a, 0xffff37,
+		0xffff43, 0xffff50, 0xffff5c, 0xffff69, 0xffff76, 0xffff82, 0xffff8e, 0xffff9b,
+		0xffffa7, 0xffffb4, 0xffffc0, 0xffffcd, 0xffffda, 0xffffe6, 0xfffff3, 0xffffff,
+	}
+	return preset("Gnuplot2", colors)
+}
+
+func Ocean() Gradient {
+	colors := []uint32{
+		0x008000, 0x007e01, 0x007d02, 0x007b03, 0x007a04, 0x007805, 0x007706, 0x007507,
+		0x007408, 0x007209, 0x00710a, 0x006f0b, 0x006e0c, 0x006c0d, 0x006b0e, 0x00690f,
+		0x006810, 0x006611, 0x006512, 0x006313, 0x006214, 0x006015, 0x005f16, 0x005d17,
+		0x005c18, 0x005a19, 0x00591a, 0x00571b, 0x00561c, 0x00541d, 0x00531e, 0x00511f,
+		0x005020, 0x004e21, 0x004d22, 0x004b23, 0x004a24, 0x004825, 0x004726, 0x004527,
+		0x004428, 0x004229, 0x00412a, 0x003f2b, 0x003e2c, 0x003c2d, 0x003b2e, 0x00392f,
+		0x003830, 0x003631, 0x003532, 0x003333, 0x003134, 0x003035, 0x002f36, 0x002d37,
+		0x002c38, 0x002a39, 0x00293a, 0x00273b, 0x00263c, 0x00243d, 0x00233e, 0x00213f,
+		0x002040, 0x001e41, 0x001c42, 0x001b43, 0x001944, 0x001845, 0x001646, 0x001547,
+		0x001448, 0x001249, 0x00104a, 0x000f4b, 0x000e4c, 0x000c4d, 0x000a4e, 0x00094f,
+		0x000850, 0x000651, 0x000452, 0x000353, 0x000154, 0x000055, 0x000156, 0x000357,
+		0x000458, 0x000659, 0x00085a, 0x00095b, 0x000a5c, 0x000c5d, 0x000e5e, 0x000f5f,
+		0x001060, 0x001261, 0x001462, 0x001563, 0x001764, 0x001865, 0x001a66, 0x001b67,
+		0x001d68, 0x001e69, 0x00206a, 0x00216b, 0x00226c, 0x00246d, 0x00266e, 0x00276f,
+		0x002970, 0x002a71, 0x002c72, 0x002d73, 0x002f74, 0x003075, 0x003176, 0x003377,
+		0x003478, 0x003679, 0x00387a, 0x00397b, 0x003a7c, 0x003c7d, 0x003e7e, 0x003f7f,
+		0x004180, 0x004281, 0x004382, 0x004583, 0x004784, 0x004885, 0x004a86, 0x004b87,
+		0x004d88, 0x004e89, 0x00508a, 0x00518b, 0x00538c, 0x00548d, 0x00568e, 0x00578f,
+		0x005990, 0x005a91, 0x005b92, 0x005d93, 0x005f94, 0x006095, 0x006296, 0x006397,
+		0x006498, 0x006699, 0x00679a, 0x00699b, 0x006b9c, 0x006c9d, 0x006e9e, 0x006f9f,
+		0x0071a0, 0x0072a1, 0x0073a2, 0x0075a3, 0x0077a4, 0x0078a5, 0x007aa6, 0x007ba7,
+		0x007da8, 0x007ea9, 0x0080aa, 0x0381ab, 0x0683ac, 0x0984ad, 0x0c85ae, 0x0f87af,
+		0x1288b0, 0x158ab1, 0x188bb2, 0x1b8db3, 0x1e8fb4, 0x2190b5, 0x2492b6, 0x2793b7,
+		0x2a94b8, 0x2d96b9, 0x3097ba, 0x3399bb, 0x369bbc, 0x399cbd, 0x3c9ebe, 0x3f9fbf,
+		0x42a1c0, 0x45a2c1, 0x48a3c2, 0x4ba5c3, 0x4ea7c4, 0x51a8c5, 0x54aac6, 0x57abc7,
+		0x5aadc8, 0x5daec9, 0x60b0ca, 0x63b1cb, 0x66b3cc, 0x69b4cd, 0x6cb6ce, 0x6fb7cf,
+		0x72b9d0, 0x75bad1, 0x78bcd2, 0x7bbdd3, 0x7ebfd4, 0x81c0d5, 0x84c2d6, 0x87c3d7,
+		0x8ac4d8, 0x8dc6d9, 0x90c7da, 0x93c9db, 0x96cbdc, 0x99ccdd, 0x9ccede, 0x9fcfdf,
+		0xa2d1e0, 0xa5d2e1, 0xa8d3e2, 0xabd5e3, 0xaed7e4, 0xb1d8e5, 0xb4dae6, 0xb7dbe7,
+		0xbadde8, 0xbddee9, 0xc0e0ea, 0xc3e1eb, 0xc6e3ec, 0xc9e4ed, 0xcce5ee, 0xcfe7ef,
+		0xd2e8f0, 0xd5eaf1, 0xd8ebf2, 0xdbedf3, 0xdeeff4, 0xe1f0f5, 0xe4f2f6, 0xe7f3f7,
+		0xeaf4f8, 0xedf6f9, 0xf0f7fa, 0xf3f9fb, 0xf6fbfc, 0xf9fcfd, 0xfcfefe, 0xffffff,
+	}
+	return preset("Ocean", colors)
+}
+
+func Bone() Gradient {
+	colors := []uint32{
+		0x000000, 0x010101, 0x020202, 0x030304, 0x030305, 0x040406, 0x050507, 0x060609,
+		0x07070a, 0x08080b, 0x09090c, 0x0a0a0d, 0x0b0a0f, 0x0b0b10, 0x0c0c11, 0x0d0d12,
+		0x0e0e13, 0x0f0f15, 0x101016, 0x111117, 0x111118, 0x12121a, 0x13131b, 0x14141c,
+		0x15151d, 0x16161e, 0x171720, 0x181821, 0x181822, 0x191923, 0x1a1a25, 0x1b1b26,
+		0x1c1c27, 0x1d1d28, 0x1e1e29, 0x1f1f2b, 0x1f1f2c, 0x20202d, 0x21212e, 0x22222f,
+		0x232331, 0x242432, 0x252533, 0x262634, 0x272636, 0x272737, 0x282838, 0x292939,
+		0x2a2a3a, 0x2b2b3c, 0x2c2c3d, 0x2d2d3e, 0x2e2d3f, 0x2e2e41, 0x2f2f42, 0x303043,
+		0x313144, 0x323245, 0x333347, 0x343448, 0x343449, 0x35354a, 0x36364b, 0x37374d,
+		0x38384e, 0x39394f, 0x3a3a50, 0x3b3b52, 0x3b3b53, 0x3c3c54, 0x3d3d55, 0x3e3e56,
+		0x3f3f58, 0x404059, 0x41415a, 0x42425b, 0x42425d, 0x43435e, 0x44445f, 0x454560,
+		0x464661, 0x474763, 0x484864, 0x494965, 0x4a4966, 0x4a4a67, 0x4b4b69, 0x4c4c6a,
+		0x4d4d6b, 0x4e4e6c, 0x4f4f6e, 0x50506f, 0x515070, 0x515171, 0x525372, 0x535473,
+		0x545574, 0x555675, 0x565776, 0x575976, 0x575a77, 0x585b78, 0x595c79, 0x5a5d7a,
+		0x5b5f7b, 0x5c607c, 0x5d617d, 0x5e627d, 0x5e637e, 0x5f657f, 0x606680, 0x616781,
+		0x626882, 0x636983, 0x646b84, 0x656c84, 0x666d85, 0x666e86, 0x676f87, 0x687188,
+		0x697289, 0x6a738a, 0x6b748b, 0x6c758b, 0x6c778c, 0x6d788d, 0x6e798e, 0x6f7a8f,
+		0x707b90, 0x717d91, 0x727e92, 0x737f92, 0x748093, 0x748194, 0x758395, 0x768496,
+		0x778597, 0x788698, 0x798799, 0x7a8999, 0x7b8a9a, 0x7b8b9b, 0x7c8c9c, 0x7d8d9d,
+		0x7e8f9e, 0x7f909f, 0x8091a0, 0x8192a0, 0x8294a1, 0x8295a2, 0x8396a3, 0x8497a4,
+		0x8598a5, 0x869aa6, 0x879ba7, 0x889ca7, 0x899da8, 0x899ea9, 0x8aa0aa, 0x8ba1ab,
+		0x8ca2ac, 0x8da3ad, 0x8ea4ae, 0x8fa6ae, 0x90a7af, 0x90a8b0, 0x91a9b1, 0x92aab2,
+		0x93acb3, 0x94adb4, 0x95aeb5, 0x96afb5, 0x97b0b6, 0x97b2b7, 0x98b3b8, 0x99b4b9,
+		0x9ab5ba, 0x9bb6bb, 0x9cb8bc, 0x9db9bc, 0x9ebabd, 0x9ebbbe, 0x9fbcbf, 0xa0bec0,
+		0xa1bfc1, 0xa2c0c2, 0xa3c1c3, 0xa4c2c3, 0xa4c4c4, 0xa5c5c5, 0xa6c6c6, 0xa7c7c7,
+		0xa9c8c8, 0xaac9c9, 0xaccaca, 0xadcbca, 0xaecbcb, 0xb0cccc, 0xb1cdcd, 0xb2cece,
+		0xb4cfcf, 0xb5d0d0, 0xb7d1d1, 0xb8d1d1, 0xb9d2d2, 0xbbd3d3, 0xbcd4d4, 0xbdd5d5,
+		0xbfd6d6, 0xc0d7d7, 0xc1d8d8, 0xc3d8d8, 0xc4d9d9, 0xc6dada, 0xc7dbdb, 0xc8dcdc,
+		0xcadddd, 0xcbdede, 0xccdfdf, 0xcedfdf, 0xcfe0e0, 0xd1e1e1, 0xd2e2e2, 0xd3e3e3,
+		0xd5e4e4, 0xd6e5e5, 0xd7e6e6, 0xd9e6e6, 0xdae7e7, 0xdbe8e8, 0xdde9e9, 0xdeeaea,
+		0xe0ebeb, 0xe1ecec, 0xe2eded, 0xe4eded, 0xe5eeee, 0xe6efef, 0xe8f0f0, 0xe9f1f1,
+		0xeaf2f2, 0xecf3f3, 0xedf4f4, 0xeff4f4, 0xf0f5f5, 0xf1f6f6, 0xf3f7f7, 0xf4f8f8,
+		0xf5f9f9, 0xf7fafa, 0xf8fbfb, 0xfafbfb, 0xfbfcfc, 0xfcfdfd, 0xfefefe, 0xffffff,
+	}
+	return preset("Bone", colors)
+}
+
+func Copper() Gradient {
+	colors := []uint32{
+		0x000000, 0x010100, 0x030201, 0x040201, 0x050302, 0x060402, 0x080503, 0x090503,
+		0x0a0604, 0x0b0704, 0x0d0805, 0x0e0905, 0x0f0906, 0x100a06, 0x120b07, 0x130c07,
+		0x140c08, 0x150d08, 0x160e09, 0x180f09, 0x19100a, 0x1a100a, 0x1c110b, 0x1d120b,
+		0x1e130c, 0x1f140c, 0x21140d, 0x22150d, 0x23160e, 0x24170e, 0x26170f, 0x27180f,
+		0x281910, 0x291a10, 0x2b1b11, 0x2c1b11, 0x2d1c12, 0x2e1d12, 0x301e13, 0x311e13,
+		0x321f14, 0x332014, 0x352115, 0x362215, 0x372216, 0x382316, 0x3a2417, 0x3b2517,
+		0x3c2518, 0x3d2618, 0x3f2719, 0x402819, 0x41291a, 0x42291a, 0x442a1b, 0x452b1b,
+		0x462c1c, 0x472d1c, 0x492d1d, 0x4a2e1d, 0x4b2f1e, 0x4c301e, 0x4e301f, 0x4f311f,
+		0x503220, 0x513320, 0x533421, 0x543421, 0x553522, 0x563622, 0x583723, 0x593723,
+		0x5a3824, 0x5b3924, 0x5d3a25, 0x5e3b25, 0x5f3b26, 0x603c26, 0x623d27, 0x633e27,
+		0x643e28, 0x653f28, 0x674029, 0x684129, 0x69422a, 0x6a422a, 0x6c432b, 0x6d442b,
+		0x6e452c, 0x6f462c, 0x71462d, 0x72472d, 0x73482e, 0x74492e, 0x76492f, 0x774a2f,
+		0x784b30, 0x794c30, 0x7b4d31, 0x7c4d31, 0x7d4e32, 0x7e4f32, 0x805033, 0x815033,
+		0x825134, 0x835234, 0x855335, 0x865435, 0x875436, 0x885536, 0x8a5637, 0x8b5737,
+		0x8c5738, 0x8d5838, 0x8f5939, 0x905a39, 0x915b3a, 0x925b3a, 0x945c3b, 0x955d3b,
+		0x965e3c, 0x975f3c, 0x995f3d, 0x9a603d, 0x9b613e, 0x9c623e, 0x9e623f, 0x9f633f,
+		0xa06440, 0xa16540, 0xa36641, 0xa46641, 0xa56742, 0xa66842, 0xa86943, 0xa96943,
+		0xaa6a44, 0xab6b44, 0xac6c45, 0xae6d45, 0xaf6d46, 0xb06e46, 0xb26f47, 0xb37047,
+		0xb47048, 0xb57148, 0xb67249, 0xb87349, 0xb9744a, 0xba744a, 0xbc754b, 0xbd764b,
+		0xbe774c, 0xbf784c, 0xc1784d, 0xc2794d, 0xc37a4e, 0xc47b4e, 0xc67b4f, 0xc77c4f,
+		0xc87d50, 0xc97e50, 0xcb7f51, 0xcc7f51, 0xcd8052, 0xce8152, 0xd08253, 0xd18253,
+		0xd28354, 0xd38454, 0xd48555, 0xd68655, 0xd78656, 0xd88756, 0xda8857, 0xdb8957,
+		0xdc8958, 0xdd8a58, 0xdf8b59, 0xe08c59, 0xe18d5a, 0xe28d5a, 0xe48e5b, 0xe58f5b,
+		0xe6905c, 0xe7915c, 0xe9915d, 0xea925d, 0xeb935e, 0xec945e, 0xee945f, 0xef955f,
+		0xf09660, 0xf19760, 0xf39861, 0xf49861, 0xf59962, 0xf69a62, 0xf89b63, 0xf99b63,
+		0xfa9c64, 0xfb9d64, 0xfc9e64, 0xfe9f65, 0xff9f65, 0xffa066, 0xffa166, 0xffa267,
+		0xffa267, 0xffa368, 0xffa468, 0xffa569, 0xffa669, 0xffa66a, 0xffa76a, 0xffa86b,
+		0xffa96b, 0xffaa6c, 0xffaa6c, 0xffab6d, 0xffac6d, 0xffad6e, 0xffad6e, 0xffae6f,
+		0xffaf6f, 0xffb070, 0xffb170, 0xffb171, 0xffb271, 0xffb372, 0xffb472, 0xffb473,
+		0xffb573, 0xffb674, 0xffb774, 0xffb875, 0xffb875, 0xffb976, 0xffba76, 0xffbb77,
+		0xffbb77, 0xffbc78, 0xffbd78, 0xffbe79, 0xffbf79, 0xffbf7a, 0xffc07a, 0xffc17b,
+		0xffc27b, 0xffc37c, 0xffc37c, 0xffc47d, 0xffc57d, 0xffc67e, 0xffc67e, 0xffc77f,
+	}
+	return preset("Copper", colors)
+}
+
+func Spring() Gradient {
+	colors := []uint32{0xff00ff, 0xffff00}
+	return preset("Spring", colors)
+}
+
+func Summer() Gradient {
+	colors := []uint32{0x008066, 0xffff66}
+	return preset("Summer", colors)
+}
+
+func Autumn() Gradient {
+	colors := []uint32{0xff0000, 0xffff00}
+	return preset("Autumn", colors)
+}
+
+func Winter() Gradient {
+	colors := []uint32{0x0000ff, 0x00ff80}
+	return preset("Winter", colors)
+}
+
+func Bwr() Gradient {
+	colors := []uint32{
+		0x0000ff, 0x0202ff, 0x0404ff, 0x0606ff, 0x0808ff, 0x0a0aff, 0x0c0cff, 0x0e0eff,
+		0x1010ff, 0x1212ff, 0x1414ff, 0x1616ff, 0x1818ff, 0x1a1aff, 0x1c1cff, 0x1e1eff,
+		0x2020ff, 0x2222ff, 0x2424ff, 0x2626ff, 0x2828ff, 0x2a2aff, 0x2c2cff, 0x2e2eff,
+		0x3030ff, 0x3232ff, 0x3434ff, 0x3636ff, 0x3838ff, 0x3a3aff, 0x3c3cff, 0x3e3eff,
+		0x4040ff, 0x4242ff, 0x4444ff, 0x4646ff, 0x4848ff, 0x4a4aff, 0x4c4cff, 0x4e4eff,
+		0x5050ff, 0x5252ff, 0x5454ff, 0x5656ff, 0x5858ff, 0x5a5aff, 0x5c5cff, 0x5e5eff,
+		0x6060ff, 0x6262ff, 0x6464ff, 0x6666ff, 0x6868ff, 0x6a6aff, 0x6c6cff, 0x6e6eff,
+		0x7070ff, 0x7272ff, 0x7474ff, 0x7676ff, 0x7878ff, 0x7a7aff, 0x7c7cff, 0x7e7eff,
+		0x8080ff, 0x8282ff, 0x8484ff, 0x8686ff, 0x8888ff, 0x8a8aff, 0x8c8cff, 0x8e8eff,
+		0x9090ff, 0x9292ff, 0x9494ff, 0x9696ff, 0x9898ff, 0x9a9aff, 0x9c9cff, 0x9e9eff,
+		0xa0a0ff, 0xa2a2ff, 0xa4a4ff, 0xa6a6ff, 0xa8a8ff, 0xaaaaff, 0xacacff, 0xaeaeff,
+		0xb0b0ff, 0xb2b2ff, 0xb4b4ff, 0xb6b6ff, 0xb8b8ff, 0xbabaff, 0xbcbcff, 0xbebeff,
+		0xc0c0ff, 0xc2c2ff, 0xc4c4ff, 0xc6c6ff, 0xc8c8ff, 0xcacaff, 0xccccff, 0xceceff,
+		0xd0d0ff, 0xd2d2ff, 0xd4d4ff, 0xd6d6ff, 0xd8d8ff, 0xdadaff, 0xdcdcff, 0xdedeff,
+		0xe0e0ff, 0xe2e2ff, 0xe4e4ff, 0xe6e6ff, 0xe8e8ff, 0xeaeaff, 0xececff, 0xeeeeff,
+		0xf0f0ff, 0xf2f2ff, 0xf4f4ff, 0xf6f6ff, 0xf8f8ff, 0xfafaff, 0xfcfcff, 0xfefeff,
+		0xfffefe, 0xfffcfc, 0xfffafa, 0xfff8f8, 0xfff6f6, 0xfff4f4, 0xfff2f2, 0xfff0f0,
+		0xffeeee, 0xffecec, 0xffeaea, 0xffe8e8, 0xffe6e6, 0xffe4e4, 0xffe2e2, 0xffe0e0,
+		0xffdede, 0xffdcdc, 0xffdada, 0xffd8d8, 0xffd6d6, 0xffd4d4, 0xffd2d2, 0xffd0d0,
+		0xffcece, 0xffcccc, 0xffcaca, 0xffc8c8, 0xffc6c6, 0xffc4c4, 0xffc2c2, 0xffc0c0,
+		0xffbebe, 0xffbcbc, 0xffbaba, 0xffb8b8, 0xffb6b6, 0xffb4b4, 0xffb2b2, 0xffb0b0,
+		0xffaeae, 0xffacac, 0xffaaaa, 0xffa8a8, 0xffa6a6, 0xffa4a4, 0xffa2a2, 0xffa0a0,
+		0xff9e9e, 0xff9c9c, 0xff9a9a, 0xff9898, 0xff9696, 0xff9494, 0xff9292, 0xff9090,
+		0xff8e8e, 0xff8c8c, 0xff8a8a, 0xff8888, 0xff8686, 0xff8484, 0xff8282, 0xff8080,
+		0xff7e7e, 0xff7c7c, 0xff7a7a, 0xff7878, 0xff7676, 0xff7474, 0xff7272, 0xff7070,
+		0xff6e6e, 0xff6c6c, 0xff6a6a, 0xff6868, 0xff6666, 0xff6464, 0xff6262, 0xff6060,
+		0xff5e5e, 0xff5c5c, 0xff5a5a, 0xff5858, 0xff5656, 0xff5454, 0xff5252, 0xff5050,
+		0xff4e4e, 0xff4c4c, 0xff4a4a, 0xff4848, 0xff4646, 0xff4444, 0xff4242, 0xff4040,
+		0xff3e3e, 0xff3c3c, 0xff3a3a, 0xff3838, 0xff3636, 0xff3434, 0xff3232, 0xff3030,
+		0xff2e2e, 0xff2c2c, 0xff2a2a, 0xff2828, 0xff2626, 0xff2424, 0xff2222, 0xff2020,
+		0xff1e1e, 0xff1c1c, 0xff1a1a, 0xff1818, 0xff1616, 0xff1414, 0xff1212, 0xff1010,
+		0xff0e0e, 0xff0c0c, 0xff0a0a, 0xff0808, 0xff0606, 0xff0404, 0xff0202, 0xff0000,
+	}
+	return preset("Bwr", colors)
+}
+
+func Seismic() Gradient {
+	colors := []uint32{
+		0x00004d, 0x00004f, 0x000052, 0x000055, 0x000058, 0x00005a, 0x00005d, 0x000060,
+		0x000063, 0x000066, 0x000069, 0x00006b, 0x00006e, 0x000071, 0x000074, 0x000077,
+		0x000079, 0x00007c, 0x00007f, 0x000082, 0x000084, 0x000087, 0x00008a, 0x00008d,
+		0x000090, 0x000093, 0x000095, 0x000098, 0x00009b, 0x00009e, 0x0000a1, 0x0000a3,
+		0x0000a6, 0x0000a9, 0x0000ac, 0x0000af, 0x0000b1, 0x0000b4, 0x0000b7, 0x0000ba,
+		0x0000bc, 0x0000bf, 0x0000c2, 0x0000c5, 0x0000c8, 0x0000cb, 0x0000cd, 0x0000d0,
+		0x0000d3, 0x0000d6, 0x0000d8, 0x0000db, 0x0000de, 0x0000e1, 0x0000e4, 0x0000e6,
+		0x0000e9, 0x0000ec, 0x0000ef, 0x0000f2, 0x0000f4, 0x0000f7, 0x0000fa, 0x0000fd,
+		0x0101ff, 0x0505ff, 0x0909ff, 0x0d0dff, 0x1111ff, 0x1515ff, 0x1919ff, 0x1d1dff,
+		0x2121ff, 0x2525ff, 0x2929ff, 0x2d2dff, 0x3131ff, 0x3535ff, 0x3939ff, 0x3d3dff,
+		0x4141ff, 0x4545ff, 0x4949ff, 0x4d4dff, 0x5151ff, 0x5555ff, 0x5959ff, 0x5d5dff,
+		0x6161ff, 0x6565ff, 0x6969ff, 0x6d6dff, 0x7171ff, 0x7575ff, 0x7979ff, 0x7d7dff,
+		0x8181ff, 0x8585ff, 0x8989ff, 0x8d8dff, 0x9191ff, 0x9595ff, 0x9999ff, 0x9d9dff,
+		0xa1a1ff, 0xa5a5ff, 0xa9a9ff, 0xadadff, 0xb1b1ff, 0xb5b5ff, 0xb9b9ff, 0xbdbdff,
+		0xc1c1ff, 0xc5c5ff, 0xc9c9ff, 0xcdcdff, 0xd1d1ff, 0xd5d5ff, 0xd9d9ff, 0xddddff,
+		0xe1e1ff, 0xe5e5ff, 0xe9e9ff, 0xededff, 0xf1f1ff, 0xf5f5ff, 0xf9f9ff, 0xfdfdff,
+		0xfffdfd, 0xfff9f9, 0xfff5f5, 0xfff1f1, 0xffeded, 0xffe9e9, 0xffe5e5, 0xffe1e1,
+		0xffdddd, 0xffd9d9, 0xffd5d5, 0xffd1d1, 0xffcdcd, 0xffc9c9, 0xffc5c5, 0xffc1c1,
+		0xffbdbd, 0xffb9b9, 0xffb5b5, 0xffb1b1, 0xffadad, 0xffa9a9, 0xffa5a5, 0xffa1a1,
+		0xff9d9d, 0xff9999, 0xff9595, 0xff9191, 0xff8d8d, 0xff8989, 0xff8585, 0xff8181,
+		0xff7d7d, 0xff7979, 0xff7575, 0xff7171, 0xff6d6d, 0xff6969, 0xff6565, 0xff6161,
+		0xff5d5d, 0xff5959, 0xff5555, 0xff5151, 0xff4d4d, 0xff4949, 0xff4545, 0xff4141,
+		0xff3d3d, 0xff3939, 0xff3535, 0xff3131, 0xff2d2d, 0xff2929, 0xff2525, 0xff2121,
+		0xff1d1d, 0xff1919, 0xff1515, 0xff1111, 0xff0d0d, 0xff0909, 0xff0505, 0xff0101,
+		0xfe0000, 0xfc0000, 0xfa0000, 0xf80000, 0xf50000, 0xf30000, 0xf20000, 0xf00000,
+		0xee0000, 0xec0000, 0xea0000, 0xe80000, 0xe50000, 0xe30000, 0xe20000, 0xe00000,
+		0xde0000, 0xdc0000, 0xda0000, 0xd80000, 0xd50000, 0xd30000, 0xd20000, 0xd00000,
+		0xce0000, 0xcc0000, 0xca0000, 0xc80000, 0xc50000, 0xc30000, 0xc20000, 0xc00000,
+		0xbe0000, 0xbc0000, 0xba0000, 0xb80000, 0xb50000, 0xb30000, 0xb20000, 0xb00000,
+		0xae0000, 0xac0000, 0xaa0000, 0xa80000, 0xa50000, 0xa30000, 0xa20000, 0xa00000,
+		0x9e0000, 0x9c0000, 0x9a0000, 0x980000, 0x950000, 0x930000, 0x920000, 0x900000,
+		0x8e0000, 0x8c0000, 0x8a0000, 0x880000, 0x850000, 0x840000, 0x820000, 0x800000,
+	}
+	return preset("Seismic", colors)
+}
+
+// Cyclic, the hue of HSV at full saturation and value
+func HSV() Gradient {
+	colors := []uint32{
+		0xff0000, 0xff0600, 0xff0c00, 0xff1200, 0xff1800, 0xff1e00, 0xff2300, 0xff2900,
+		0xff2f00, 0xff3500, 0xff3b00, 0xff4100, 0xff4700, 0xff4d00, 0xff5300, 0xff5900,
+		0xff5f00, 0xff6400, 0xff6a00, 0xff7000, 0xff7600, 0xff7c00, 0xff8200, 0xff8800,
+		0xff8e00, 0xff9400, 0xff9a00, 0xff9f00, 0xffa500, 0xffab00, 0xffb100, 0xffb700,
+		0xffbd00, 0xffc300, 0xffc900, 0xffcf00, 0xffd500, 0xffdb00, 0xffe000, 0xffe600,
+		0xffec00, 0xfef100, 0xfcf500, 0xfaf900, 0xf8fd00, 0xf4ff00, 0xeeff00, 0xe8ff00,
+		0xe2ff00, 0xddff00, 0xd7ff00, 0xd1ff00, 0xcbff00, 0xc5ff00, 0xbfff00, 0xb9ff00,
+		0xb3ff00, 0xadff00, 0xa7ff00, 0xa2ff00, 0x9cff00, 0x96ff00, 0x90ff00, 0x8aff00,
+		0x84ff00, 0x7eff00, 0x78ff00, 0x72ff00, 0x6cff00, 0x66ff00, 0x61ff00, 0x5bff00,
+		0x55ff00, 0x4fff00, 0x49ff00, 0x43ff00, 0x3dff00, 0x37ff00, 0x31ff00, 0x2bff00,
+		0x25ff00, 0x20ff00, 0x1aff00, 0x14ff00, 0x0eff00, 0x08ff00, 0x06ff04, 0x04ff08,
+		0x02ff0c, 0x00ff10, 0x00ff16, 0x00ff1b, 0x00ff21, 0x00ff27, 0x00ff2d, 0x00ff33,
+		0x00ff39, 0x00ff3f, 0x00ff45, 0x00ff4b, 0x00ff51, 0x00ff57, 0x00ff5c, 0x00ff62,
+		0x00ff68, 0x00ff6e, 0x00ff74, 0x00ff7a, 0x00ff80, 0x00ff86, 0x00ff8c, 0x00ff92,
+		0x00ff97, 0x00ff9d, 0x00ffa3, 0x00ffa9, 0x00ffaf, 0x00ffb5, 0x00ffbb, 0x00ffc1,
+		0x00ffc7, 0x00ffcd, 0x00ffd3, 0x00ffd8, 0x00ffde, 0x00ffe4, 0x00ffea, 0x00fff0,
+		0x00fff6, 0x00fffc, 0x00fcff, 0x00f6ff, 0x00f0ff, 0x00eaff, 0x00e5ff, 0x00dfff,
+		0x00d9ff, 0x00d3ff, 0x00cdff, 0x00c7ff, 0x00c1ff, 0x00bbff, 0x00b5ff, 0x00afff,
+		0x00aaff, 0x00a4ff, 0x009eff, 0x0098ff, 0x0092ff, 0x008cff, 0x0086ff, 0x0080ff,
+		0x007aff, 0x0074ff, 0x006eff, 0x0069ff, 0x0063ff, 0x005dff, 0x0057ff, 0x0051ff,
+		0x004bff, 0x0045ff, 0x003fff, 0x0039ff, 0x0033ff, 0x002dff, 0x0028ff, 0x0022ff,
+		0x001cff, 0x0016ff, 0x0010ff, 0x020cff, 0x0408ff, 0x0604ff, 0x0800ff, 0x0e00ff,
+		0x1300ff, 0x1900ff, 0x1f00ff, 0x2500ff, 0x2b00ff, 0x3100ff, 0x3700ff, 0x3d00ff,
+		0x4300ff, 0x4900ff, 0x4f00ff, 0x5400ff, 0x5a00ff, 0x6000ff, 0x6600ff, 0x6c00ff,
+		0x7200ff, 0x7800ff, 0x7e00ff, 0x8400ff, 0x8a00ff, 0x9000ff, 0x9500ff, 0x9b00ff,
+		0xa100ff, 0xa700ff, 0xad00ff, 0xb300ff, 0xb900ff, 0xbf00ff, 0xc500ff, 0xcb00ff,
+		0xd000ff, 0xd600ff, 0xdc00ff, 0xe200ff, 0xe800ff, 0xee00ff, 0xf400ff, 0xf800fd,
+		0xfa00f9, 0xfc00f5, 0xfe00f1, 0xff00ed, 0xff00e7, 0xff00e1, 0xff00db, 0xff00d5,
+		0xff00cf, 0xff00c9, 0xff00c3, 0xff00bd, 0xff00b7, 0xff00b1, 0xff00ac, 0xff00a6,
+		0xff00a0, 0xff009a, 0xff0094, 0xff008e, 0xff0088, 0xff0082, 0xff007c, 0xff0076,
+		0xff0071, 0xff006b, 0xff0065, 0xff005f, 0xff0059, 0xff0053, 0xff004d, 0xff0047,
+		0xff0041, 0xff003b, 0xff0035, 0xff0030, 0xff002a, 0xff0024, 0xff001e, 0xff0018,
+	}
+	return preset("HSV", colors)
 }
@@ -1,6 +1,7 @@
 package colorgrad
 
 import (
+	"math"
 	"testing"
 )
 
@@ -204,4 +205,34 @@ func Test_PresetGradients(t *testing.T) {
 
 	grad = Sinebow()
 	test(t, grad.At(0).HexString(), grad.At(1).HexString())
+
+	// The last color of matplotlib's hsv is not quite red
+	testSlice(t, colors2hex(HSV().Colors(7)), []string{"#ff0000", "#fbf700", "#09ff01", "#00fff3", "#0010ff", "#eb00ff", "#ff0018"})
+}
+
+func Test_MatplotlibPresets(t *testing.T) {
+	data := []struct {
+		grad   Gradient
+		colors []string
+	}{
+		{Jet(), []string{"#000080", "#0080ff", "#7bff7c", "#ff9700", "#800000"}},
+		{Hot(), []string{"#0b0000", "#b20000", "#ff5a00", "#ffff04", "#ffffff"}},
+		{Gnuplot(), []string{"#000000", "#8004ff", "#b42002", "#dd6c00", "#ffff00"}},
+		{Ocean(), []string{"#008000", "#002040", "#004080", "#40a0bf", "#ffffff"}},
+		{Afmhot(), []string{"#000000", "#7f0000", "#fe8001", "#ffff80", "#ffffff"}},
+		{Copper(), []string{"#000000", "#503220", "#a06440", "#ef955f", "#ffc77f"}},
+		{Summer(), []string{"#008066", "#40a066", "#80c066", "#bfdf66", "#ffff66"}},
+		{Bwr(), []string{"#0000ff", "#7f7fff", "#fefefe", "#ff8080", "#ff0000"}},
+		{Seismic(), []string{"#00004d", "#0101fe", "#fefdfe", "#ff0101", "#800000"}},
+	}
+	for _, d := range data {
+		testSlice(t, colors2hex(d.grad.Colors(5)), d.colors)
+	}
+
+	for _, grad := range []Gradient{Bone(), Spring(), Autumn(), Winter(), Gnuplot2()} {
+		test(t, len(grad.Colors(5)), 5)
+	}
+	test(t, Bone().At(1).HexString(), "#ffffff")
+	test(t, Gnuplot2().At(1).HexString(), "#ffffff")
+	test(t, Jet().At(math.NaN()), Color{A: 1})
 }
@@ -0,0 +1,224 @@
+package colorgrad
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type PresetCategory int
+
+const (
+	CategorySequential PresetCategory = iota
+	CategoryDiverging
+	CategoryCyclical
+	CategoryQualitative
+	// Maps of none of the above, e.g. rainbows
+	CategoryMiscellaneous
+)
+
+func (c PresetCategory) String() string {
+	switch c {
+	case CategorySequential:
+		return "CategorySequential"
+	case CategoryDiverging:
+		return "CategoryDiverging"
+	case CategoryCyclical:
+		return "CategoryCyclical"
+	case CategoryQualitative:
+		return "CategoryQualitative"
+	case CategoryMiscellaneous:
+		return "CategoryMiscellaneous"
+	}
+	return ""
+}
+
+type PresetInfo struct {
+	// Unique name, compared case-insensitively
+	Name     string
+	Category PresetCategory
+	// Readable with the common color vision deficiencies
+	ColorblindSafe bool
+	// Equal steps in position are equal perceived color differences
+	PerceptuallyUniform bool
+	// Create the gradient
+	New func() Gradient
+}
+
+type presetRegistry struct {
+	mu      sync.RWMutex
+	presets []PresetInfo
+	// Index in presets by lowercase name
+	index map[string]int
+}
+
+var registry = newPresetRegistry([]PresetInfo{
+	// Diverging
+	{"BrBG", CategoryDiverging, true, false, BrBG},
+	{"PRGn", CategoryDiverging, true, false, PRGn},
+	{"PiYG", CategoryDiverging, true, false, PiYG},
+	{"PuOr", CategoryDiverging, true, false, PuOr},
+	{"RdBu", CategoryDiverging, true, false, RdBu},
+	{"RdGy", CategoryDiverging, false, false, RdGy},
+	{"RdYlBu", CategoryDiverging, true, false, RdYlBu},
+	{"RdYlGn", CategoryDiverging, false, false, RdYlGn},
+	{"Spectral", CategoryDiverging, false, false, Spectral},
+
+	// Sequential (single hue)
+	{"Blues", CategorySequential, true, false, Blues},
+	{"Greens", CategorySequential, true, false, Greens},
+	{"Greys", CategorySequential, true, false, Greys},
+	{"Oranges", CategorySequential, true, false, Oranges},
+	{"Purples", CategorySequential, true, false, Purples},
+	{"Reds", CategorySequential, true, false, Reds},
+
+	// Sequential (multi-hue)
+	{"Turbo", CategoryMiscellaneous, false, false, Turbo},
+	{"Viridis", CategorySequential, true, true, Viridis},
+	{"Inferno", CategorySequential, true, true, Inferno},
+	{"Magma", CategorySequential, true, true, Magma},
+	{"Plasma", CategorySequential, true, true, Plasma},
+	{"Cividis", CategorySequential, true, true, Cividis},
+	{"Warm", CategorySequential, false, false, Warm},
+	{"Cool", CategorySequential, false, false, Cool},
+	{"CubehelixDefault", CategorySequential, true, false, CubehelixDefault},
+	{"BuGn", CategorySequential, true, false, BuGn},
+	{"BuPu", CategorySequential, true, false, BuPu},
+	{"GnBu", CategorySequential, true, false, GnBu},
+	{"OrRd", CategorySequential, true, false, OrRd},
+	{"PuBuGn", CategorySequential, true, false, PuBuGn},
+	{"PuBu", CategorySequential, true, false, PuBu},
+	{"PuRd", CategorySequential, true, false, PuRd},
+	{"RdPu", CategorySequential, true, false, RdPu},
+	{"YlGnBu", CategorySequential, true, false, YlGnBu},
+	{"YlGn", CategorySequential, true, false, YlGn},
+	{"YlOrBr", CategorySequential, true, false, YlOrBr},
+	{"YlOrRd", CategorySequential, true, false, YlOrRd},
+
+	// Cyclical
+	{"Rainbow", CategoryCyclical, false, false, Rainbow},
+	{"Sinebow", CategoryCyclical, false, false, Sinebow},
+	{"HSV", CategoryCyclical, false, false, HSV},
+
+	// matplotlib
+	{"Jet", CategoryMiscellaneous, false, false, Jet},
+	{"Hot", CategorySequential, false, false, Hot},
+	{"Afmhot", CategorySequential, false, false, Afmhot},
+	{"Gnuplot", CategoryMiscellaneous, false, false, Gnuplot},
+	{"Gnuplot2", CategoryMiscellaneous, false, false, Gnuplot2},
+	{"Ocean", CategoryMiscellaneous, false, false, Ocean},
+	{"Bone", CategorySequential, true, false, Bone},
+	{"Copper", CategorySequential, true, false, Copper},
+	{"Spring", CategorySequential, false, false, Spring},
+	{"Summer", CategorySequential, false, false, Summer},
+	{"Autumn", CategorySequential, false, false, Autumn},
+	{"Winter", CategorySequential, false, false, Winter},
+	{"Bwr", CategoryDiverging, true, false, Bwr},
+	{"Seismic", CategoryDiverging, true, false, Seismic},
+
+	// Qualitative
+	palettePreset("Category10", Category10),
+	palettePreset("Accent", Accent),
+	palettePreset("Dark2", Dark2),
+	palettePreset("Paired", Paired),
+	palettePreset("Pastel1", Pastel1),
+	palettePreset("Pastel2", Pastel2),
+	palettePreset("Set1", Set1),
+	palettePreset("Set2", Set2),
+	palettePreset("Set3", Set3),
+	palettePreset("Tableau10", Tableau10),
+})
+
+// Palette as a hard-edged gradient. None is colorblind safe at its full
+// size: ColorBrewer rates Dark2, Paired and Set2 safe only up to 3 or 4
+// classes.
+func palettePreset(name string, p func() Palette) PresetInfo {
+	return PresetInfo{name, CategoryQualitative, false, false, func() Gradient {
+		grad := p().Gradient()
+		grad.spec = &GradientSpec{Preset: name}
+		return grad
+	}}
+}
+
+func newPresetRegistry(presets []PresetInfo) *presetRegistry {
+	r := &presetRegistry{index: map[string]int{}}
+	for _, p := range presets {
+		if err := r.register(p); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+func (r *presetRegistry) register(p PresetInfo) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("invalid preset name %q", p.Name)
+	}
+	if p.New == nil {
+		return fmt.Errorf("preset %q has no New function", p.Name)
+	}
+	key := strings.ToLower(p.Name)
+	if _, ok := r.index[key]; ok {
+		return fmt.Errorf("preset %q already registered", p.Name)
+	}
+	r.index[key] = len(r.presets)
+	r.presets = append(r.presets, p)
+	return nil
+}
+
+func (r *presetRegistry) lookup(name string) (PresetInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i, ok := r.index[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return PresetInfo{}, false
+	}
+	return r.presets[i], true
+}
+
+// All presets, the built-in ones first, then the registered ones in the order
+// of registration
+func Presets() []PresetInfo {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return append([]PresetInfo(nil), registry.presets...)
+}
+
+// Presets of a category, in the order of Presets
+func PresetsByCategory(category PresetCategory) []PresetInfo {
+	res := []PresetInfo{}
+	for _, p := range Presets() {
+		if p.Category == category {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// Find a preset by name, ignoring case
+func LookupPreset(name string) (PresetInfo, bool) {
+	return registry.lookup(name)
+}
+
+// Create the preset with the given name, ignoring case. Its Spec refers to
+// the preset by name.
+func Preset(name string) (Gradient, error) {
+	p, ok := registry.lookup(name)
+	if !ok {
+		return zeroGrad(), fmt.Errorf("unknown preset %q", name)
+	}
+	grad := p.New()
+	grad.spec = &GradientSpec{Preset: p.Name}
+	return grad, nil
+}
+
+// Add an application defined preset, available to Preset, LookupPreset and
+// GradientSpec. Returns an error if a preset with the same name, ignoring
+// case, exists.
+func RegisterPreset(p PresetInfo) error {
+	return registry.register(p)
+}
@@ -0,0 +1,92 @@
+package colorgrad
+
+import (
+	"testing"
+)
+
+func Test_PresetRegistry(t *testing.T) {
+	// Built-in presets, followed by those of Test_RegisterPreset
+	presets := Presets()[:63]
+	test(t, presets[0].Name, "BrBG")
+
+	for _, p := range presets {
+		spec, ok := p.New().Spec()
+		testTrue(t, ok)
+		test(t, spec.Preset, p.Name)
+	}
+
+	p, ok := LookupPreset("viridis")
+	testTrue(t, ok)
+	test(t, p.Name, "Viridis")
+	test(t, p.Category, CategorySequential)
+	testTrue(t, p.ColorblindSafe)
+	testTrue(t, p.PerceptuallyUniform)
+
+	p, ok = LookupPreset(" RDBU ")
+	testTrue(t, ok)
+	test(t, p.Name, "RdBu")
+	test(t, p.Category, CategoryDiverging)
+
+	_, ok = LookupPreset("Foo")
+	testTrue(t, !ok)
+
+	cyclical := PresetsByCategory(CategoryCyclical)
+	test(t, len(cyclical), 3)
+	test(t, cyclical[0].Name, "Rainbow")
+	test(t, cyclical[2].Name, "HSV")
+	test(t, len(PresetsByCategory(CategoryDiverging)), 11)
+
+	// Rainbows
+	misc := []string{}
+	for _, p := range PresetsByCategory(CategoryMiscellaneous) {
+		misc = append(misc, p.Name)
+	}
+	testSlice(t, misc, []string{"Turbo", "Jet", "Gnuplot", "Gnuplot2", "Ocean"})
+	test(t, CategoryMiscellaneous.String(), "CategoryMiscellaneous")
+
+	grad, err := Preset("magma")
+	test(t, err, nil)
+	testSlice(t, colors2hex(grad.Colors(10)), colors2hex(Magma().Colors(10)))
+
+	grad, err = Preset("Foo")
+	test(t, err.Error(), `unknown preset "Foo"`)
+	testTrue(t, isZeroGradient(grad))
+}
+
+func Test_RegisterPreset(t *testing.T) {
+	fire := func() Gradient {
+		grad, _ := NewGradient().HtmlColors("black", "red", "yellow", "white").Build()
+		return grad
+	}
+	// Registered once per process
+	if _, ok := LookupPreset("TestFire"); !ok {
+		err := RegisterPreset(PresetInfo{
+			Name:     "TestFire",
+			Category: CategorySequential,
+			New:      fire,
+		})
+		test(t, err, nil)
+	}
+
+	p, ok := LookupPreset("testfire")
+	testTrue(t, ok)
+	test(t, p.Name, "TestFire")
+	test(t, Presets()[len(Presets())-1].Name, "TestFire")
+
+	// By name in a spec
+	grad, err := GradientSpec{Preset: "TESTFIRE"}.Build()
+	test(t, err, nil)
+	testSlice(t, colors2hex(grad.Colors(10)), colors2hex(fire().Colors(10)))
+	spec, ok := grad.Spec()
+	testTrue(t, ok)
+	test(t, spec.Preset, "TestFire")
+
+	err = RegisterPreset(PresetInfo{Name: "testFIRE", New: fire})
+	test(t, err.Error(), `preset "testFIRE" already registered`)
+	err = RegisterPreset(PresetInfo{Name: "viridis", New: fire})
+	testTrue(t, err != nil)
+	err = RegisterPreset(PresetInfo{Name: " ", New: fire})
+	testTrue(t, err != nil)
+	err = RegisterPreset(PresetInfo{Name: "TestNil"})
+	testTrue(t, err != nil)
+}
@@ -0,0 +1,250 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/mazznoer/colorgrad"
+)
+
+type ColorbarOptions struct {
+	// Length of the bar in pixels, without the extend arrows. Default is 256.
+	Length int
+	// Thickness of the bar in pixels. Default is 16.
+	Thickness int
+	// Vertical bar with the start of the gradient at the bottom
+	Vertical bool
+	// Tick positions, in the gradient domain or in data values if Norm is set
+	Ticks []float64
+	// Tick labels, formatted from the ticks if nil. An empty label is not
+	// drawn.
+	Labels []string
+	// Length of the tick marks in pixels. Default is 4.
+	TickLength int
+	// Integer scale of the bitmap font. Default is 1.
+	FontScale int
+	// Draw the bar as this number of flat segments, each the color at its
+	// center. Default is the number of bins of a BoundaryNorm Norm, else a
+	// continuous bar. A gradient from Sharp or Palette.Gradient is drawn
+	// with hard edges, but set it to the number of segments for one with
+	// smoothed edges.
+	Segments int
+	// Optional norm used to place the ticks, the bar shows [0..1] of the norm
+	Norm colorgrad.Norm
+	// Default is transparent
+	Background color.Color
+	// Color of ticks and labels. Default is black.
+	Foreground color.Color
+}
+
+// Colorbar draws a colorbar of grad, with tick marks and labels. An extend
+// arrow is drawn at each end where the Under or Over color of the gradient
+// differs from the end color.
+func Colorbar(grad colorgrad.Gradient, opts ColorbarOptions) *image.NRGBA {
+	if opts.Length < 1 {
+		opts.Length = 256
+	}
+	if opts.Thickness < 1 {
+		opts.Thickness = 16
+	}
+	if opts.TickLength < 1 {
+		opts.TickLength = 4
+	}
+	if opts.FontScale < 1 {
+		opts.FontScale = 1
+	}
+	if opts.Background == nil {
+		opts.Background = color.Transparent
+	}
+	if opts.Foreground == nil {
+		opts.Foreground = color.Black
+	}
+	if opts.Segments < 1 {
+		// Data values only map to the centers of the bins
+		if n, ok := opts.Norm.(colorgrad.BoundaryNorm); ok {
+			opts.Segments = len(n.Boundaries) - 1
+		}
+	}
+	fg := color.NRGBAModel.Convert(opts.Foreground).(color.NRGBA)
+
+	length := opts.Length
+	thick := opts.Thickness
+	d := grad.Max - grad.Min
+
+	// Extend arrows
+	var under, over *colorgrad.Color
+	if grad.Under != nil && *grad.Under != grad.At(grad.Min) {
+		under = grad.Under
+	}
+	if grad.Over != nil && *grad.Over != grad.At(grad.Max) {
+		over = grad.Over
+	}
+	arrow := thick
+	arrowStart, arrowEnd := 0, 0
+	if under != nil {
+		arrowStart = arrow
+	}
+	if over != nil {
+		arrowEnd = arrow
+	}
+
+	// Position of each tick along the bar in [0..1], and its label
+	type tick struct {
+		s     float64
+		label string
+	}
+	ticks := []tick{}
+	for i, v := range opts.Ticks {
+		s := (v - grad.Min) / d
+		if opts.Norm != nil {
+			s = opts.Norm.Normalize(v)
+		}
+		if math.IsNaN(s) || s < 0 || s > 1 {
+			continue
+		}
+		label := strconv.FormatFloat(v, 'g', 4, 64)
+		if opts.Labels != nil {
+			label = ""
+			if i < len(opts.Labels) {
+				label = opts.Labels[i]
+			}
+		}
+		ticks = append(ticks, tick{s, label})
+	}
+
+	// Pixel offset of a position along the bar, from the start of the bar
+	offset := func(s float64) int {
+		return int(math.Min(float64(length-1), math.Floor(s*float64(length))))
+	}
+
+	// Layout. The main axis runs along the bar, the cross axis across it.
+	gap := 2
+	padStart, padEnd := 0, 0
+	textCross := 0
+	for _, t := range ticks {
+		w, h := textSize(t.label, opts.FontScale)
+		if w == 0 {
+			continue
+		}
+		size, cross := w, h
+		if opts.Vertical {
+			size, cross = h, w
+		}
+		p := arrowStart + offset(t.s)
+		if opts.Vertical {
+			p = arrowEnd + length - 1 - offset(t.s)
+		}
+		total := arrowStart + length + arrowEnd
+		padStart = maxInt(padStart, size/2-p)
+		padEnd = maxInt(padEnd, p+size-size/2-total)
+		textCross = maxInt(textCross, cross)
+	}
+	if textCross > 0 {
+		textCross += gap
+	}
+
+	mainSize := padStart + arrowStart + length + arrowEnd + padEnd
+	crossSize := thick
+	if len(ticks) > 0 {
+		crossSize += opts.TickLength + textCross
+	}
+
+	var img *image.NRGBA
+	if opts.Vertical {
+		img = image.NewNRGBA(image.Rect(0, 0, crossSize, mainSize))
+	} else {
+		img = image.NewNRGBA(image.Rect(0, 0, mainSize, crossSize))
+	}
+	bg := color.NRGBAModel.Convert(opts.Background).(color.NRGBA)
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = bg.R, bg.G, bg.B, bg.A
+	}
+
+	// Set pixel at main and cross axis coordinates
+	set := func(m, c int, col color.NRGBA) {
+		if opts.Vertical {
+			img.SetNRGBA(c, m, col)
+		} else {
+			img.SetNRGBA(m, c, col)
+		}
+	}
+	toNRGBA := func(c colorgrad.Color) color.NRGBA {
+		r, g, b, a := c.Clamp().RGBA255()
+		return color.NRGBA{r, g, b, a}
+	}
+
+	// The bar, from the start of the gradient along the main axis. Vertical
+	// bars start at the bottom.
+	barStart := padStart + arrowStart
+	if opts.Vertical {
+		barStart = padStart + arrowEnd
+	}
+	for i := 0; i < length; i++ {
+		s := (float64(i) + 0.5) / float64(length)
+		if opts.Segments > 0 {
+			k := math.Min(math.Floor(s*float64(opts.Segments)), float64(opts.Segments-1))
+			s = (k + 0.5) / float64(opts.Segments)
+		}
+		col := toNRGBA(grad.At(grad.Min + s*d))
+		m := barStart + i
+		if opts.Vertical {
+			m = barStart + length - 1 - i
+		}
+		for c := 0; c < thick; c++ {
+			set(m, c, col)
+		}
+	}
+
+	// Extend arrows, the tip at the outer end and at the middle of the bar
+	drawArrow := func(from, dir int, col colorgrad.Color) {
+		nc := toNRGBA(col)
+		for k := 0; k < arrow; k++ {
+			half := (float64(k) + 0.5) / float64(arrow) * float64(thick) / 2
+			for c := 0; c < thick; c++ {
+				if math.Abs(float64(c)+0.5-float64(thick)/2) <= half {
+					set(from+dir*k, c, nc)
+				}
+			}
+		}
+	}
+	if opts.Vertical {
+		if under != nil {
+			drawArrow(barStart+length+arrow-1, -1, *under)
+		}
+		if over != nil {
+			drawArrow(barStart-arrow, 1, *over)
+		}
+	} else {
+		if under != nil {
+			drawArrow(barStart-arrow, 1, *under)
+		}
+		if over != nil {
+			drawArrow(barStart+length+arrow-1, -1, *over)
+		}
+	}
+
+	// Ticks and labels
+	for _, t := range ticks {
+		m := barStart + offset(t.s)
+		if opts.Vertical {
+			m = barStart + length - 1 - offset(t.s)
+		}
+		for c := thick; c < thick+opts.TickLength; c++ {
+			set(m, c, fg)
+		}
+		w, h := textSize(t.label, opts.FontScale)
+		if w == 0 {
+			continue
+		}
+		c := thick + opts.TickLength + gap
+		if opts.Vertical {
+			drawText(img, c, m-h/2, t.label, opts.FontScale, fg)
+		} else {
+			drawText(img, m-w/2, c, t.label, opts.FontScale, fg)
+		}
+	}
+
+	return img
+}
@@ -0,0 +1,86 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/mazznoer/colorgrad"
+)
+
+func Test_Colorbar(t *testing.T) {
+	grad, _ := colorgrad.NewGradient().HtmlColors("#000", "#fff").Domain(0, 100).Build()
+
+	// Bar only
+	img := Colorbar(grad, ColorbarOptions{Length: 100, Thickness: 10})
+	test(t, img.Bounds(), image.Rect(0, 0, 100, 10))
+	test(t, img.NRGBAAt(0, 5), color.NRGBA{1, 1, 1, 255})
+	test(t, img.NRGBAAt(99, 5), color.NRGBA{254, 254, 254, 255})
+
+	// Ticks and labels
+	img = Colorbar(grad, ColorbarOptions{Length: 100, Thickness: 10, Ticks: []float64{0, 50, 100, 200}})
+	// The labels "0" and "100" overflow the bar by 2 and 8 pixels
+	test(t, img.Bounds(), image.Rect(0, 0, 110, 10+4+2+7))
+	test(t, img.NRGBAAt(52, 12), color.NRGBA{0, 0, 0, 255})
+	test(t, img.NRGBAAt(53, 12), color.NRGBA{})
+	// Top of the "0" label
+	test(t, img.NRGBAAt(0, 16), color.NRGBA{})
+	test(t, img.NRGBAAt(1, 16), color.NRGBA{0, 0, 0, 255})
+
+	// Vertical, start of the gradient at the bottom
+	img = Colorbar(grad, ColorbarOptions{Length: 100, Thickness: 10, Vertical: true, Labels: []string{}, Ticks: []float64{50}})
+	test(t, img.Bounds(), image.Rect(0, 0, 14, 100))
+	test(t, img.NRGBAAt(5, 99), color.NRGBA{1, 1, 1, 255})
+	test(t, img.NRGBAAt(12, 49), color.NRGBA{0, 0, 0, 255})
+
+	// Segments
+	img = Colorbar(grad, ColorbarOptions{Length: 100, Thickness: 10, Segments: 2})
+	test(t, img.NRGBAAt(0, 5), img.NRGBAAt(49, 5))
+	test(t, img.NRGBAAt(0, 5), color.NRGBA{64, 64, 64, 255})
+	test(t, img.NRGBAAt(50, 5), color.NRGBA{191, 191, 191, 255})
+
+	// Norm
+	img = Colorbar(grad, ColorbarOptions{Length: 100, Thickness: 10, Labels: []string{}, Ticks: []float64{10}, Norm: colorgrad.LogNorm{Vmin: 1, Vmax: 100}})
+	test(t, img.NRGBAAt(50, 12), color.NRGBA{0, 0, 0, 255})
+
+	// BoundaryNorm, a flat segment per bin
+	img = Colorbar(grad, ColorbarOptions{Length: 100, Thickness: 10, Norm: colorgrad.BoundaryNorm{Boundaries: []float64{0, 1, 10, 100, 1000}}})
+	test(t, img.NRGBAAt(0, 5), img.NRGBAAt(24, 5))
+	test(t, img.NRGBAAt(0, 5), color.NRGBA{32, 32, 32, 255})
+	test(t, img.NRGBAAt(99, 5), color.NRGBA{223, 223, 223, 255})
+
+	// Palette, hard edges
+	img = Colorbar(colorgrad.Set1().Gradient(), ColorbarOptions{Length: 90, Thickness: 10})
+	for i, col := range colorgrad.Set1() {
+		r, g, b, _ := col.RGBA255()
+		test(t, img.NRGBAAt(i*10, 5), color.NRGBA{r, g, b, 255})
+		test(t, img.NRGBAAt(i*10+9, 5), color.NRGBA{r, g, b, 255})
+	}
+
+	// Extend arrows
+	red := colorgrad.Rgb(1, 0, 0, 1)
+	white := colorgrad.Rgb(1, 1, 1, 1)
+	grad.Under = &red
+	grad.Over = &white
+	img = Colorbar(grad, ColorbarOptions{Length: 100, Thickness: 10, Background: color.White})
+	test(t, img.Bounds(), image.Rect(0, 0, 110, 10))
+	test(t, img.NRGBAAt(1, 5), color.NRGBA{255, 0, 0, 255})
+	test(t, img.NRGBAAt(1, 0), color.NRGBA{255, 255, 255, 255})
+	test(t, img.NRGBAAt(9, 0), color.NRGBA{255, 0, 0, 255})
+	test(t, img.NRGBAAt(10, 5), color.NRGBA{1, 1, 1, 255})
+}
+
+func Test_Text(t *testing.T) {
+	w, h := textSize("", 1)
+	test(t, w, 0)
+	test(t, h, 0)
+	w, h = textSize("-1.5e3", 2)
+	test(t, w, 70)
+	test(t, h, 14)
+
+	img := image.NewNRGBA(image.Rect(0, 0, 5, 7))
+	drawText(img, 0, 0, "l", 1, color.NRGBA{0, 0, 0, 255})
+	test(t, img.NRGBAAt(0, 6), color.NRGBA{0, 0, 0, 255})
+	test(t, img.NRGBAAt(4, 6), color.NRGBA{0, 0, 0, 255})
+	test(t, img.NRGBAAt(1, 0), color.NRGBA{})
+}
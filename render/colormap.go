@@ -0,0 +1,278 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/mazznoer/colorgrad"
+)
+
+type ColormapOptions struct {
+	// Maps the source values to [0..1], applied to the raw pixel or data
+	// values. Default is the full range of the pixel type, or the range of
+	// the finite data values.
+	Norm colorgrad.Norm
+	// Number of entries of the lookup table used for 16-bit and float
+	// values. Default is 1024. 8-bit values use a table of every value.
+	LutSize int
+	// Number of goroutines. Default is one per CPU for images of at least
+	// 256x256 pixels, one otherwise. Destinations other than *image.RGBA and
+	// *image.NRGBA are always filled by one goroutine.
+	Workers int
+}
+
+// Precomputed colors, non-premultiplied and premultiplied
+type colormapLut struct {
+	nrgba []color.NRGBA
+	rgba  []color.RGBA
+}
+
+func (lut *colormapLut) add(col colorgrad.Color) {
+	r, g, b, a := col.Clamp().RGBA255()
+	c := color.NRGBA{r, g, b, a}
+	lut.nrgba = append(lut.nrgba, c)
+	lut.rgba = append(lut.rgba, color.RGBAModel.Convert(c).(color.RGBA))
+}
+
+// Lookup table over [0..1] of the norm, followed by the under, over and NaN
+// colors.
+type normLut struct {
+	colormapLut
+	size int
+}
+
+func newNormLut(grad colorgrad.Gradient, size int) *normLut {
+	lut := &normLut{size: size}
+	d := grad.Max - grad.Min
+	for i := 0; i < size; i++ {
+		t := grad.Min + float64(i)/float64(size-1)*d
+		// Rounding may overshoot max
+		if i == size-1 {
+			t = grad.Max
+		}
+		lut.add(grad.At(t))
+	}
+	lut.add(grad.At(grad.Min - math.Max(d, 1)))
+	lut.add(grad.At(grad.Max + math.Max(d, 1)))
+	lut.add(grad.At(math.NaN()))
+	return lut
+}
+
+// Index of the color for a normalized value
+func (lut *normLut) index(t float64) int {
+	switch {
+	case math.IsNaN(t):
+		return lut.size + 2
+	case t < 0:
+		return lut.size
+	case t > 1:
+		return lut.size + 1
+	}
+	return int(t*float64(lut.size-1) + 0.5)
+}
+
+// ColormapGray fills dst with the colors of grad for each pixel of src. The
+// pixel at src.Rect.Min is written to dst.Bounds().Min, pixels outside dst
+// are skipped.
+func ColormapGray(dst draw.Image, src *image.Gray, grad colorgrad.Gradient, opts ColormapOptions) {
+	var n colorgrad.Norm = colorgrad.LinearNorm{Vmin: 0, Vmax: 255}
+	if opts.Norm != nil {
+		n = opts.Norm
+	}
+	lut := &colormapLut{}
+	ng := grad.WithNorm(n)
+	for i := 0; i < 256; i++ {
+		lut.add(ng.At(float64(i)))
+	}
+
+	colormapRows(dst, src.Rect.Dx(), src.Rect.Dy(), opts.Workers, lut, func(y int, idx []int) {
+		row := src.Pix[y*src.Stride : y*src.Stride+len(idx)]
+		for x, v := range row {
+			idx[x] = int(v)
+		}
+	})
+}
+
+// ColormapGrayNRGBA returns a new image with the bounds of src filled as by
+// ColormapGray.
+func ColormapGrayNRGBA(src *image.Gray, grad colorgrad.Gradient, opts ColormapOptions) *image.NRGBA {
+	dst := image.NewNRGBA(src.Rect)
+	ColormapGray(dst, src, grad, opts)
+	return dst
+}
+
+// ColormapGrayRGBA returns a new alpha-premultiplied image with the bounds of
+// src filled as by ColormapGray.
+func ColormapGrayRGBA(src *image.Gray, grad colorgrad.Gradient, opts ColormapOptions) *image.RGBA {
+	dst := image.NewRGBA(src.Rect)
+	ColormapGray(dst, src, grad, opts)
+	return dst
+}
+
+// ColormapGray16 fills dst with the colors of grad for each pixel of src, as
+// ColormapGray.
+func ColormapGray16(dst draw.Image, src *image.Gray16, grad colorgrad.Gradient, opts ColormapOptions) {
+	var n colorgrad.Norm = colorgrad.LinearNorm{Vmin: 0, Vmax: 65535}
+	if opts.Norm != nil {
+		n = opts.Norm
+	}
+	lut := newNormLut(grad, lutSize(opts))
+
+	colormapRows(dst, src.Rect.Dx(), src.Rect.Dy(), opts.Workers, &lut.colormapLut, func(y int, idx []int) {
+		row := src.Pix[y*src.Stride : y*src.Stride+len(idx)*2]
+		for x := range idx {
+			v := uint16(row[x*2])<<8 | uint16(row[x*2+1])
+			idx[x] = lut.index(n.Normalize(float64(v)))
+		}
+	})
+}
+
+// ColormapGray16NRGBA returns a new image with the bounds of src filled as by
+// ColormapGray16.
+func ColormapGray16NRGBA(src *image.Gray16, grad colorgrad.Gradient, opts ColormapOptions) *image.NRGBA {
+	dst := image.NewNRGBA(src.Rect)
+	ColormapGray16(dst, src, grad, opts)
+	return dst
+}
+
+// ColormapGray16RGBA returns a new alpha-premultiplied image with the bounds
+// of src filled as by ColormapGray16.
+func ColormapGray16RGBA(src *image.Gray16, grad colorgrad.Gradient, opts ColormapOptions) *image.RGBA {
+	dst := image.NewRGBA(src.Rect)
+	ColormapGray16(dst, src, grad, opts)
+	return dst
+}
+
+// ColormapData fills dst with the colors of grad for each value of a grid of
+// width columns stored by rows. Value (x, y) is written to dst.Bounds().Min
+// + (x, y), values outside dst are skipped. NaN uses the NaN color of grad.
+func ColormapData(dst draw.Image, data []float64, width int, grad colorgrad.Gradient, opts ColormapOptions) error {
+	if width <= 0 || len(data)%width != 0 {
+		return fmt.Errorf("invalid data size %d for width %d", len(data), width)
+	}
+
+	n := opts.Norm
+	if n == nil {
+		vmin, vmax := math.Inf(1), math.Inf(-1)
+		for _, v := range data {
+			if !math.IsNaN(v) && !math.IsInf(v, 0) {
+				vmin = math.Min(vmin, v)
+				vmax = math.Max(vmax, v)
+			}
+		}
+		if vmin > vmax {
+			vmin, vmax = 0, 1
+		} else if vmin == vmax {
+			vmax = vmin + 1
+		}
+		n = colorgrad.LinearNorm{Vmin: vmin, Vmax: vmax}
+	}
+	lut := newNormLut(grad, lutSize(opts))
+
+	colormapRows(dst, width, len(data)/width, opts.Workers, &lut.colormapLut, func(y int, idx []int) {
+		row := data[y*width : (y+1)*width]
+		for x := range idx {
+			idx[x] = lut.index(n.Normalize(row[x]))
+		}
+	})
+	return nil
+}
+
+// ColormapDataNRGBA returns a new image of width x len(data)/width pixels,
+// with its origin at (0, 0), filled as by ColormapData.
+func ColormapDataNRGBA(data []float64, width int, grad colorgrad.Gradient, opts ColormapOptions) (*image.NRGBA, error) {
+	if width <= 0 || len(data)%width != 0 {
+		return nil, fmt.Errorf("invalid data size %d for width %d", len(data), width)
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, width, len(data)/width))
+	return dst, ColormapData(dst, data, width, grad, opts)
+}
+
+// ColormapDataRGBA returns a new alpha-premultiplied image of width x
+// len(data)/width pixels, with its origin at (0, 0), filled as by
+// ColormapData.
+func ColormapDataRGBA(data []float64, width int, grad colorgrad.Gradient, opts ColormapOptions) (*image.RGBA, error) {
+	if width <= 0 || len(data)%width != 0 {
+		return nil, fmt.Errorf("invalid data size %d for width %d", len(data), width)
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, len(data)/width))
+	return dst, ColormapData(dst, data, width, grad, opts)
+}
+
+func lutSize(opts ColormapOptions) int {
+	if opts.LutSize < 2 {
+		return 1024
+	}
+	return opts.LutSize
+}
+
+// Fill dst by rows of lookup table indices, the rows are split between
+// workers.
+func colormapRows(dst draw.Image, w, h, workers int, lut *colormapLut, indices func(y int, idx []int)) {
+	b := dst.Bounds()
+	w = minInt(w, b.Dx())
+	h = minInt(h, b.Dy())
+	if w <= 0 || h <= 0 {
+		return
+	}
+
+	rgba, _ := dst.(*image.RGBA)
+	nrgba, _ := dst.(*image.NRGBA)
+
+	if workers < 1 {
+		workers = 1
+		if w*h >= 256*256 {
+			workers = runtime.GOMAXPROCS(0)
+		}
+	}
+	if rgba == nil && nrgba == nil {
+		workers = 1
+	}
+	workers = minInt(workers, h)
+
+	fill := func(y0, y1 int) {
+		idx := make([]int, w)
+		for y := y0; y < y1; y++ {
+			indices(y, idx)
+			switch {
+			case nrgba != nil:
+				p := nrgba.PixOffset(b.Min.X, b.Min.Y+y)
+				for x, i := range idx {
+					c := lut.nrgba[i]
+					nrgba.Pix[p+x*4], nrgba.Pix[p+x*4+1], nrgba.Pix[p+x*4+2], nrgba.Pix[p+x*4+3] = c.R, c.G, c.B, c.A
+				}
+			case rgba != nil:
+				p := rgba.PixOffset(b.Min.X, b.Min.Y+y)
+				for x, i := range idx {
+					c := lut.rgba[i]
+					rgba.Pix[p+x*4], rgba.Pix[p+x*4+1], rgba.Pix[p+x*4+2], rgba.Pix[p+x*4+3] = c.R, c.G, c.B, c.A
+				}
+			default:
+				for x, i := range idx {
+					dst.Set(b.Min.X+x, b.Min.Y+y, lut.nrgba[i])
+				}
+			}
+		}
+	}
+
+	if workers == 1 {
+		fill(0, h)
+		return
+	}
+
+	var wg sync.WaitGroup
+	rows := (h + workers - 1) / workers
+	for y := 0; y < h; y += rows {
+		wg.Add(1)
+		go func(y0, y1 int) {
+			defer wg.Done()
+			fill(y0, y1)
+		}(y, minInt(y+rows, h))
+	}
+	wg.Wait()
+}
@@ -0,0 +1,187 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/mazznoer/colorgrad"
+)
+
+func Test_ColormapGray(t *testing.T) {
+	grad, _ := colorgrad.NewGradient().HtmlColors("#000", "#f00").Build()
+
+	src := image.NewGray(image.Rect(10, 10, 13, 11))
+	src.SetGray(10, 10, color.Gray{0})
+	src.SetGray(11, 10, color.Gray{128})
+	src.SetGray(12, 10, color.Gray{255})
+
+	dst := image.NewNRGBA(image.Rect(0, 0, 3, 1))
+	ColormapGray(dst, src, grad, ColormapOptions{})
+	test(t, dst.NRGBAAt(0, 0), color.NRGBA{0, 0, 0, 255})
+	test(t, dst.NRGBAAt(1, 0), color.NRGBA{128, 0, 0, 255})
+	test(t, dst.NRGBAAt(2, 0), color.NRGBA{255, 0, 0, 255})
+
+	// Premultiplied destination, smaller than the source
+	grad, _ = colorgrad.NewGradient().Colors(colorgrad.Rgb(1, 0, 0, 0), colorgrad.Rgb(1, 0, 0, 1)).Build()
+	rgba := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	ColormapGray(rgba, src, grad, ColormapOptions{})
+	test(t, rgba.RGBAAt(0, 0), color.RGBA{0, 0, 0, 0})
+	test(t, rgba.RGBAAt(1, 0), color.RGBA{128, 0, 0, 128})
+
+	// Norm with out of range values
+	grad, _ = colorgrad.NewGradient().HtmlColors("#000", "#fff").UnderColor(colorgrad.Rgb(0, 0, 1, 1)).Build()
+	ColormapGray(dst, src, grad, ColormapOptions{Norm: colorgrad.LinearNorm{Vmin: 100, Vmax: 200}})
+	test(t, dst.NRGBAAt(0, 0), color.NRGBA{0, 0, 255, 255})
+	test(t, dst.NRGBAAt(1, 0), color.NRGBA{71, 71, 71, 255})
+	test(t, dst.NRGBAAt(2, 0), color.NRGBA{255, 255, 255, 255})
+}
+
+func Test_ColormapGray16(t *testing.T) {
+	grad, _ := colorgrad.NewGradient().HtmlColors("#000", "#fff").Build()
+
+	src := image.NewGray16(image.Rect(0, 0, 3, 1))
+	src.SetGray16(0, 0, color.Gray16{0})
+	src.SetGray16(1, 0, color.Gray16{32768})
+	src.SetGray16(2, 0, color.Gray16{65535})
+
+	// Any draw.Image
+	dst := image.NewGray(image.Rect(0, 0, 3, 1))
+	ColormapGray16(dst, src, grad, ColormapOptions{LutSize: 3})
+	testSliceU8(t, dst.Pix, []uint8{0, 128, 255})
+}
+
+func Test_ColormapData(t *testing.T) {
+	grad, _ := colorgrad.NewGradient().
+		HtmlColors("#000", "#fff").
+		NaNColor(colorgrad.Rgb(1, 0, 0, 1)).
+		Build()
+
+	data := []float64{
+		-1, 0, 1,
+		3, math.NaN(), math.Inf(1),
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	err := ColormapData(dst, data, 3, grad, ColormapOptions{})
+	test(t, err, nil)
+	test(t, dst.NRGBAAt(0, 0), color.NRGBA{0, 0, 0, 255})
+	test(t, dst.NRGBAAt(1, 0), color.NRGBA{64, 64, 64, 255})
+	test(t, dst.NRGBAAt(0, 1), color.NRGBA{255, 255, 255, 255})
+	test(t, dst.NRGBAAt(1, 1), color.NRGBA{255, 0, 0, 255})
+	test(t, dst.NRGBAAt(2, 1), color.NRGBA{255, 255, 255, 255})
+
+	// The largest value is at the domain max, not past it
+	grad, _ = colorgrad.NewGradient().
+		HtmlColors("#000", "#fff").
+		Domain(0.3, 0.9).
+		OverColor(colorgrad.Rgb(0, 0, 1, 1)).
+		Build()
+	err = ColormapData(dst, []float64{0.3, 0.6, 0.9}, 3, grad, ColormapOptions{})
+	test(t, err, nil)
+	test(t, dst.NRGBAAt(2, 0), color.NRGBA{255, 255, 255, 255})
+	err = ColormapData(dst, []float64{0.3, 0.6, 0.9}, 3, grad, ColormapOptions{Norm: colorgrad.LinearNorm{Vmin: 0.3, Vmax: 0.9}})
+	test(t, err, nil)
+	test(t, dst.NRGBAAt(2, 0), color.NRGBA{255, 255, 255, 255})
+
+	err = ColormapData(dst, data, 4, grad, ColormapOptions{})
+	testTrue(t, err != nil)
+	err = ColormapData(dst, data, 0, grad, ColormapOptions{})
+	testTrue(t, err != nil)
+}
+
+func Test_ColormapNewImage(t *testing.T) {
+	grad, _ := colorgrad.NewGradient().Colors(colorgrad.Rgb(1, 0, 0, 0), colorgrad.Rgb(1, 0, 0, 1)).Build()
+
+	src := image.NewGray(image.Rect(10, 10, 13, 11))
+	src.SetGray(11, 10, color.Gray{128})
+	src.SetGray(12, 10, color.Gray{255})
+
+	nrgba := ColormapGrayNRGBA(src, grad, ColormapOptions{})
+	test(t, nrgba.Rect, src.Rect)
+	test(t, nrgba.NRGBAAt(11, 10), color.NRGBA{255, 0, 0, 128})
+	rgba := ColormapGrayRGBA(src, grad, ColormapOptions{})
+	test(t, rgba.Rect, src.Rect)
+	test(t, rgba.RGBAAt(11, 10), color.RGBA{128, 0, 0, 128})
+
+	src16 := image.NewGray16(image.Rect(0, 0, 2, 1))
+	src16.SetGray16(1, 0, color.Gray16{65535})
+	nrgba = ColormapGray16NRGBA(src16, grad, ColormapOptions{})
+	test(t, nrgba.Rect, src16.Rect)
+	test(t, nrgba.NRGBAAt(1, 0), color.NRGBA{255, 0, 0, 255})
+	rgba = ColormapGray16RGBA(src16, grad, ColormapOptions{})
+	test(t, rgba.RGBAAt(0, 0), color.RGBA{0, 0, 0, 0})
+
+	data := []float64{0, 1, 2, 3, 4, 4}
+	nrgba, err := ColormapDataNRGBA(data, 3, grad, ColormapOptions{})
+	test(t, err, nil)
+	test(t, nrgba.Rect, image.Rect(0, 0, 3, 2))
+	test(t, nrgba.NRGBAAt(1, 1), color.NRGBA{255, 0, 0, 255})
+	rgba, err = ColormapDataRGBA(data, 2, grad, ColormapOptions{})
+	test(t, err, nil)
+	test(t, rgba.Rect, image.Rect(0, 0, 2, 3))
+	test(t, rgba.RGBAAt(0, 0), color.RGBA{0, 0, 0, 0})
+
+	_, err = ColormapDataNRGBA(data, 4, grad, ColormapOptions{})
+	testTrue(t, err != nil)
+	_, err = ColormapDataRGBA(data, 0, grad, ColormapOptions{})
+	testTrue(t, err != nil)
+}
+
+func Test_ColormapParallel(t *testing.T) {
+	w, h := 300, 257
+	data := make([]float64, w*h)
+	for i := range data {
+		data[i] = math.Sin(float64(i) / 100)
+	}
+	grad := colorgrad.Turbo()
+
+	serial := image.NewRGBA(image.Rect(0, 0, w, h))
+	test(t, ColormapData(serial, data, w, grad, ColormapOptions{Workers: 1}), nil)
+
+	for _, workers := range []int{0, 3, 1000} {
+		parallel := image.NewRGBA(image.Rect(0, 0, w, h))
+		test(t, ColormapData(parallel, data, w, grad, ColormapOptions{Workers: workers}), nil)
+		testSliceU8(t, parallel.Pix, serial.Pix)
+	}
+}
+
+func Benchmark_ColormapData(b *testing.B) {
+	w, h := 1024, 1024
+	data := make([]float64, w*h)
+	for i := range data {
+		data[i] = math.Sin(float64(i) / 100)
+	}
+	grad := colorgrad.Turbo()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	for _, workers := range []int{1, 0} {
+		b.Run(map[int]string{1: "serial", 0: "parallel"}[workers], func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ColormapData(dst, data, w, grad, ColormapOptions{Workers: workers})
+			}
+		})
+	}
+}
+
+func testTrue(t *testing.T, b bool) {
+	if !b {
+		t.Helper()
+		t.Errorf("it false")
+	}
+}
+
+func testSliceU8(t *testing.T, a, b []uint8) {
+	if len(a) != len(b) {
+		t.Helper()
+		t.Errorf("different length -> left: %v, right: %v", len(a), len(b))
+		return
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Helper()
+			t.Errorf("diff at index: %v, left: %v, right: %v", i, a[i], b[i])
+			return
+		}
+	}
+}
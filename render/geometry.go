@@ -0,0 +1,136 @@
+package render
+
+import (
+	"math"
+)
+
+// Geometry maps a point in image coordinates to a gradient position, where 0
+// and 1 are the start and the end of the gradient.
+type Geometry interface {
+	Position(x, y float64) float64
+}
+
+type linear struct {
+	x0, y0 float64
+	dx, dy float64
+	len2   float64
+}
+
+// Linear gradient from (x0, y0) to (x1, y1)
+func Linear(x0, y0, x1, y1 float64) Geometry {
+	dx := x1 - x0
+	dy := y1 - y0
+	return linear{x0, y0, dx, dy, dx*dx + dy*dy}
+}
+
+func (g linear) Position(x, y float64) float64 {
+	if g.len2 == 0 {
+		return 0
+	}
+	return ((x-g.x0)*g.dx + (y-g.y0)*g.dy) / g.len2
+}
+
+type radial struct {
+	fx, fy float64
+	// Center relative to the focal point
+	cdx, cdy float64
+	r        float64
+	a        float64
+}
+
+// Radial gradient centered at (cx, cy) with radius r
+func Radial(cx, cy, r float64) Geometry {
+	return RadialFocal(cx, cy, r, cx, cy)
+}
+
+// Radial gradient centered at (cx, cy) with radius r, starting from the
+// focal point (fx, fy) as in SVG. A focal point outside the circle is moved
+// to just inside it.
+func RadialFocal(cx, cy, r, fx, fy float64) Geometry {
+	cdx := cx - fx
+	cdy := cy - fy
+	if d := math.Hypot(cdx, cdy); d > r*0.999 {
+		s := r * 0.999 / d
+		cdx *= s
+		cdy *= s
+		fx = cx - cdx
+		fy = cy - cdy
+	}
+	return radial{fx, fy, cdx, cdy, r, cdx*cdx + cdy*cdy - r*r}
+}
+
+func (g radial) Position(x, y float64) float64 {
+	if g.r <= 0 {
+		return 0
+	}
+	// Solve |p - f - t*(c - f)| = t*r for t >= 0
+	dx := x - g.fx
+	dy := y - g.fy
+	b := -2 * (dx*g.cdx + dy*g.cdy)
+	c := dx*dx + dy*dy
+	return (-b - math.Sqrt(b*b-4*g.a*c)) / (2 * g.a)
+}
+
+type conic struct {
+	cx, cy float64
+	angle  float64
+}
+
+// Conic gradient around (cx, cy), starting at angle degrees clockwise from
+// the top, as in CSS conic-gradient().
+func Conic(cx, cy, angle float64) Geometry {
+	return conic{cx, cy, angle}
+}
+
+func (g conic) Position(x, y float64) float64 {
+	a := math.Atan2(x-g.cx, g.cy-y) * 180 / math.Pi
+	t := math.Mod((a-g.angle)/360, 1)
+	if t < 0 {
+		t += 1
+	}
+	return t
+}
+
+type diamond struct {
+	cx, cy float64
+	r      float64
+}
+
+// Diamond gradient centered at (cx, cy), reaching the end at a Manhattan
+// distance r.
+func Diamond(cx, cy, r float64) Geometry {
+	return diamond{cx, cy, r}
+}
+
+func (g diamond) Position(x, y float64) float64 {
+	if g.r <= 0 {
+		return 0
+	}
+	return (math.Abs(x-g.cx) + math.Abs(y-g.cy)) / g.r
+}
+
+type bilinear struct {
+	x0, y0, x1, y1     float64
+	t00, t10, t01, t11 float64
+}
+
+// Bilinear interpolation of the positions at the corners of the rectangle
+// from (x0, y0) to (x1, y1): t00 top left, t10 top right, t01 bottom left and
+// t11 bottom right.
+func Bilinear(x0, y0, x1, y1, t00, t10, t01, t11 float64) Geometry {
+	return bilinear{x0, y0, x1, y1, t00, t10, t01, t11}
+}
+
+func (g bilinear) Position(x, y float64) float64 {
+	u := 0.0
+	if g.x1 != g.x0 {
+		u = math.Max(0, math.Min(1, (x-g.x0)/(g.x1-g.x0)))
+	}
+	v := 0.0
+	if g.y1 != g.y0 {
+		v = math.Max(0, math.Min(1, (y-g.y0)/(g.y1-g.y0)))
+	}
+	top := g.t00 + u*(g.t10-g.t00)
+	bottom := g.t01 + u*(g.t11-g.t01)
+	return top + v*(bottom-top)
+}
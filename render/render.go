@@ -0,0 +1,165 @@
+// Package render paints colorgrad gradients into images.
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/mazznoer/colorgrad"
+)
+
+// How the gradient continues outside [0..1]
+type Spread int
+
+const (
+	// Extend the end colors, or use the Under and Over colors of the gradient
+	SpreadPad Spread = iota
+	// Repeat the gradient, as Gradient.RepeatAt
+	SpreadRepeat
+	// Mirror the gradient, as Gradient.ReflectAt
+	SpreadReflect
+)
+
+func (s Spread) String() string {
+	switch s {
+	case SpreadPad:
+		return "SpreadPad"
+	case SpreadRepeat:
+		return "SpreadRepeat"
+	case SpreadReflect:
+		return "SpreadReflect"
+	}
+	return ""
+}
+
+// Dithering applied when quantizing to 8 bits per channel
+type Dither int
+
+const (
+	DitherNone Dither = iota
+	// 8x8 Bayer matrix
+	DitherOrdered
+	// Floyd–Steinberg error diffusion
+	DitherFloydSteinberg
+)
+
+func (d Dither) String() string {
+	switch d {
+	case DitherNone:
+		return "DitherNone"
+	case DitherOrdered:
+		return "DitherOrdered"
+	case DitherFloydSteinberg:
+		return "DitherFloydSteinberg"
+	}
+	return ""
+}
+
+type Options struct {
+	Spread Spread
+	Dither Dither
+}
+
+var bayer8 = [8][8]float64{
+	{0, 32, 8, 40, 2, 34, 10, 42},
+	{48, 16, 56, 24, 50, 18, 58, 26},
+	{12, 44, 4, 36, 14, 46, 6, 38},
+	{60, 28, 52, 20, 62, 30, 54, 22},
+	{3, 35, 11, 43, 1, 33, 9, 41},
+	{51, 19, 59, 27, 49, 17, 57, 25},
+	{15, 47, 7, 39, 13, 45, 5, 37},
+	{63, 31, 55, 23, 61, 29, 53, 21},
+}
+
+// Fill paints the rectangle r of dst with grad. Each pixel center is mapped
+// to a position by geom, and positions [0..1] cover the gradient domain.
+func Fill(dst draw.Image, r image.Rectangle, grad colorgrad.Gradient, geom Geometry, opts Options) {
+	r = r.Intersect(dst.Bounds())
+	if r.Empty() {
+		return
+	}
+
+	at := grad.At
+	switch opts.Spread {
+	case SpreadRepeat:
+		at = grad.RepeatAt
+	case SpreadReflect:
+		at = grad.ReflectAt
+	}
+	d := grad.Max - grad.Min
+
+	// Quantization error of the current and the next row, per channel
+	var errCur, errNext [][4]float64
+	if opts.Dither == DitherFloydSteinberg {
+		errCur = make([][4]float64, r.Dx()+2)
+		errNext = make([][4]float64, r.Dx()+2)
+	}
+
+	nrgba, _ := dst.(*image.NRGBA)
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			t := geom.Position(float64(x)+0.5, float64(y)+0.5)
+			col := at(grad.Min + t*d).Clamp()
+			v := [4]float64{col.R * 255, col.G * 255, col.B * 255, col.A * 255}
+
+			var q [4]uint8
+			switch opts.Dither {
+			case DitherOrdered:
+				th := (bayer8[y&7][x&7]+0.5)/64 - 0.5
+				for i := range v {
+					q[i] = quantize(v[i] + th)
+				}
+			case DitherFloydSteinberg:
+				i := x - r.Min.X + 1
+				for c := range v {
+					want := v[c] + errCur[i][c]
+					q[c] = quantize(want)
+					e := want - float64(q[c])
+					errCur[i+1][c] += e * 7 / 16
+					errNext[i-1][c] += e * 3 / 16
+					errNext[i][c] += e * 5 / 16
+					errNext[i+1][c] += e * 1 / 16
+				}
+			default:
+				for i := range v {
+					q[i] = quantize(v[i])
+				}
+			}
+
+			if nrgba != nil {
+				p := nrgba.PixOffset(x, y)
+				copy(nrgba.Pix[p:p+4], q[:])
+			} else {
+				dst.Set(x, y, color.NRGBA{q[0], q[1], q[2], q[3]})
+			}
+		}
+
+		if errCur != nil {
+			errCur, errNext = errNext, errCur
+			for i := range errNext {
+				errNext[i] = [4]float64{}
+			}
+		}
+	}
+}
+
+func quantize(v float64) uint8 {
+	return uint8(math.Max(0, math.Min(255, math.Round(v))))
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,118 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/mazznoer/colorgrad"
+)
+
+func testNear(t *testing.T, a, b float64) {
+	t.Helper()
+	if math.Abs(a-b) > 1e-9 {
+		t.Errorf("left: %v, right: %v", a, b)
+	}
+}
+
+func Test_Geometry(t *testing.T) {
+	g := Linear(0, 0, 10, 0)
+	testNear(t, g.Position(0, 5), 0)
+	testNear(t, g.Position(5, 5), 0.5)
+	testNear(t, g.Position(20, 0), 2)
+	testNear(t, Linear(1, 1, 1, 1).Position(5, 5), 0)
+
+	g = Radial(10, 10, 10)
+	testNear(t, g.Position(10, 10), 0)
+	testNear(t, g.Position(15, 10), 0.5)
+	testNear(t, g.Position(10, 0), 1)
+
+	// Focal point at the left, the circle still ends at radius 10
+	g = RadialFocal(10, 10, 10, 5, 10)
+	testNear(t, g.Position(5, 10), 0)
+	testNear(t, g.Position(0, 10), 1)
+	testNear(t, g.Position(20, 10), 1)
+	testNear(t, g.Position(12.5, 10), 0.5)
+
+	g = Conic(0, 0, 0)
+	testNear(t, g.Position(0, -1), 0)
+	testNear(t, g.Position(1, 0), 0.25)
+	testNear(t, g.Position(0, 1), 0.5)
+	testNear(t, g.Position(-1, 0), 0.75)
+	testNear(t, Conic(0, 0, 90).Position(1, 0), 0)
+
+	g = Diamond(0, 0, 10)
+	testNear(t, g.Position(5, 5), 1)
+	testNear(t, g.Position(-2, 3), 0.5)
+
+	g = Bilinear(0, 0, 10, 10, 0, 1, 1, 0)
+	testNear(t, g.Position(0, 0), 0)
+	testNear(t, g.Position(10, 0), 1)
+	testNear(t, g.Position(5, 5), 0.5)
+	testNear(t, g.Position(20, 20), 0)
+}
+
+func Test_Fill(t *testing.T) {
+	grad, _ := colorgrad.NewGradient().HtmlColors("#000", "#fff").Build()
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 1))
+	Fill(img, img.Bounds(), grad, Linear(0, 0, 4, 0), Options{})
+	test(t, img.RGBAAt(0, 0), color.RGBA{32, 32, 32, 255})
+	test(t, img.RGBAAt(3, 0), color.RGBA{223, 223, 223, 255})
+
+	// Repeat and reflect
+	nimg := image.NewNRGBA(image.Rect(0, 0, 4, 1))
+	Fill(nimg, nimg.Bounds(), grad, Linear(0, 0, 2, 0), Options{Spread: SpreadRepeat})
+	test(t, nimg.NRGBAAt(2, 0), nimg.NRGBAAt(0, 0))
+	Fill(nimg, nimg.Bounds(), grad, Linear(0, 0, 2, 0), Options{Spread: SpreadReflect})
+	test(t, nimg.NRGBAAt(2, 0), nimg.NRGBAAt(1, 0))
+	Fill(nimg, nimg.Bounds(), grad, Linear(0, 0, 2, 0), Options{})
+	test(t, nimg.NRGBAAt(3, 0), color.NRGBA{255, 255, 255, 255})
+
+	// Only the intersection is painted
+	img = image.NewRGBA(image.Rect(0, 0, 4, 4))
+	Fill(img, image.Rect(2, 2, 10, 10), grad, Linear(0, 0, 4, 0), Options{})
+	test(t, img.RGBAAt(1, 1), color.RGBA{})
+	test(t, img.RGBAAt(3, 3), color.RGBA{223, 223, 223, 255})
+
+	// Gray image
+	gray := image.NewGray(image.Rect(0, 0, 2, 1))
+	Fill(gray, gray.Bounds(), grad, Linear(0, 0, 2, 0), Options{})
+	test(t, gray.GrayAt(1, 0).Y, uint8(191))
+}
+
+func Test_Dither(t *testing.T) {
+	// A flat color halfway between two 8-bit levels
+	v := 100.5 / 255
+	grad, _ := colorgrad.NewGradient().Colors(colorgrad.Rgb(v, v, v, 1)).Build()
+
+	for _, d := range []Dither{DitherOrdered, DitherFloydSteinberg} {
+		img := image.NewNRGBA(image.Rect(0, 0, 16, 16))
+		Fill(img, img.Bounds(), grad, Linear(0, 0, 16, 0), Options{Dither: d})
+
+		sum := 0
+		for y := 0; y < 16; y++ {
+			for x := 0; x < 16; x++ {
+				c := img.NRGBAAt(x, y)
+				if c.R != 100 && c.R != 101 {
+					t.Errorf("%v: unexpected level %v", d, c.R)
+				}
+				test(t, c.A, uint8(255))
+				sum += int(c.R)
+			}
+		}
+		// Mean preserved
+		testNear(t, math.Round(float64(sum)/256*2)/2, 100.5)
+	}
+
+	test(t, DitherNone.String(), "DitherNone")
+	test(t, SpreadReflect.String(), "SpreadReflect")
+}
+
+func test(t *testing.T, a, b any) {
+	if a != b {
+		t.Helper()
+		t.Errorf("left: %v, right: %v", a, b)
+	}
+}
@@ -25,22 +25,14 @@ func (sg sharpGradient) At(t float64) Color {
 		return Color{A: 1}
 	}
 
-	low := 0
-	high := len(sg.positions)
-
-	for low < high {
-		mid := (low + high) / 2
-		if sg.positions[mid] < t {
-			low = mid + 1
-		} else {
-			high = mid
-		}
-	}
+	return sg.segmentAt(searchStop(sg.positions, t), t)
+}
 
-	if low == 0 {
-		low = 1
-	}
+func (sg sharpGradient) stopPositions() []float64 {
+	return sg.positions
+}
 
+func (sg sharpGradient) segmentAt(low int, t float64) Color {
 	i := low - 1
 	p1 := sg.positions[i]
 	p2 := sg.positions[low]
@@ -27,44 +27,25 @@ func (sg smoothstepGradient) At(t float64) Color {
 		return Color{A: 1}
 	}
 
-	low := 0
-	high := len(sg.positions)
-
-	for low < high {
-		mid := (low + high) / 2
-		if sg.positions[mid] < t {
-			low = mid + 1
-		} else {
-			high = mid
-		}
-	}
+	return sg.segmentAt(searchStop(sg.positions, t), t)
+}
 
-	if low == 0 {
-		low = 1
-	}
+func (sg smoothstepGradient) stopPositions() []float64 {
+	return sg.positions
+}
 
+func (sg smoothstepGradient) segmentAt(low int, t float64) Color {
 	p1 := sg.positions[low-1]
 	p2 := sg.positions[low]
 	t = (t - p1) / (p2 - p1)
 	a, b, c, d := smoothstepInterpolate(sg.colors[low-1], sg.colors[low], t)
 
-	switch sg.mode {
-	case BlendRgb:
-		return Color{R: a, G: b, B: c, A: d}
-	case BlendLinearRgb:
-		return LinearRgb(a, b, c, d)
-	case BlendLab:
-		return Lab(a, b, c, d).Clamp()
-	case BlendOklab:
-		return Oklab(a, b, c, d).Clamp()
-	}
-
-	return Color{}
+	return colorFromSpace(sg.mode, a, b, c, d)
 }
 
-func newSmoothstepGradient(colors []Color, positions []float64, mode BlendMode) Gradient {
+func newSmoothstepGradient(colors []Color, positions []float64, mode BlendMode, hue HueInterpolation) Gradient {
 	gradbase := smoothstepGradient{
-		colors:    convertColors(colors, mode),
+		colors:    convertColors(colors, mode, hue),
 		positions: positions,
 		min:       positions[0],
 		max:       positions[len(positions)-1],
@@ -0,0 +1,273 @@
+package colorgrad
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mazznoer/csscolorparser"
+)
+
+// Serializable description of a gradient: a preset, the settings of a
+// GradientBuilder or a GIMP gradient. Only one of Preset, Colors and Ggr is
+// set.
+//
+// As JSON it is an object such as
+//
+//	{"colors": ["#ff0000", "#0000ff"], "mode": "oklab", "interpolation": "catmull-rom"}
+//
+// with the fields preset, colors, domain, mode, interpolation, hue, ggr, nan,
+// under and over; or a string with the name of a preset. The text form is the
+// name of a preset without NaN, under and over colors, compact JSON
+// otherwise.
+type GradientSpec struct {
+	// Name of a preset, built-in or registered, e.g. "Viridis"
+	Preset string
+
+	// Arguments of GradientBuilder
+	Colors           []Color
+	Domain           []float64
+	Mode             BlendMode
+	Interpolation    Interpolation
+	HueInterpolation HueInterpolation
+
+	// Content of a GIMP gradient file. The foreground color is black and the
+	// background color is white, Spec reports them resolved.
+	Ggr string
+
+	NaN   *Color
+	Under *Color
+	Over  *Color
+}
+
+// Report the spec of a gradient from GradientBuilder, a preset or ParseGgr,
+// with its current NaN, under and over colors. Returns false for other
+// gradients, including those derived by Sharp, Reverse etc.
+func (g Gradient) Spec() (GradientSpec, bool) {
+	if g.spec == nil {
+		return GradientSpec{}, false
+	}
+	spec := *g.spec
+	spec.Colors = append([]Color(nil), spec.Colors...)
+	spec.Domain = append([]float64(nil), spec.Domain...)
+	spec.NaN, spec.Under, spec.Over = g.NaN, g.Under, g.Over
+	return spec, true
+}
+
+// Build the gradient described by the spec
+func (s GradientSpec) Build() (Gradient, error) {
+	var grad Gradient
+	var err error
+
+	n := 0
+	for _, set := range []bool{s.Preset != "", len(s.Colors) > 0, s.Ggr != ""} {
+		if set {
+			n++
+		}
+	}
+	if n > 1 {
+		return zeroGrad(), fmt.Errorf("invalid gradient spec: more than one of preset, colors and ggr")
+	}
+
+	switch {
+	case s.Preset != "":
+		grad, err = Preset(s.Preset)
+	case s.Ggr != "":
+		grad, _, err = ParseGgr(strings.NewReader(s.Ggr), Color{A: 1}, Color{R: 1, G: 1, B: 1, A: 1})
+	default:
+		grad, err = NewGradient().
+			Colors(s.Colors...).
+			Domain(s.Domain...).
+			Mode(s.Mode).
+			Interpolation(s.Interpolation).
+			HueInterpolation(s.HueInterpolation).
+			Build()
+	}
+	if err != nil {
+		return grad, err
+	}
+
+	grad.NaN, grad.Under, grad.Over = copyColor(s.NaN), copyColor(s.Under), copyColor(s.Over)
+	return grad, nil
+}
+
+func zeroGrad() Gradient {
+	return Gradient{
+		Core: zeroGradient{},
+		Min:  0,
+		Max:  1,
+	}
+}
+
+func copyColor(c *Color) *Color {
+	if c == nil {
+		return nil
+	}
+	col := *c
+	return &col
+}
+
+type jsonSpec struct {
+	Preset        string           `json:"preset,omitempty"`
+	Colors        []specColor      `json:"colors,omitempty"`
+	Domain        []float64        `json:"domain,omitempty"`
+	Mode          BlendMode        `json:"mode,omitempty"`
+	Interpolation Interpolation    `json:"interpolation,omitempty"`
+	Hue           HueInterpolation `json:"hue,omitempty"`
+	Ggr           string           `json:"ggr,omitempty"`
+	NaN           *specColor       `json:"nan,omitempty"`
+	Under         *specColor       `json:"under,omitempty"`
+	Over          *specColor       `json:"over,omitempty"`
+}
+
+func (s GradientSpec) MarshalJSON() ([]byte, error) {
+	js := jsonSpec{
+		Preset:        s.Preset,
+		Domain:        s.Domain,
+		Mode:          s.Mode,
+		Interpolation: s.Interpolation,
+		Hue:           s.HueInterpolation,
+		Ggr:           s.Ggr,
+		NaN:           (*specColor)(s.NaN),
+		Under:         (*specColor)(s.Under),
+		Over:          (*specColor)(s.Over),
+	}
+	for _, c := range s.Colors {
+		js.Colors = append(js.Colors, specColor(c))
+	}
+	return json.Marshal(js)
+}
+
+func (s *GradientSpec) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var name string
+		if err := json.Unmarshal(data, &name); err != nil {
+			return err
+		}
+		return s.UnmarshalText([]byte(name))
+	}
+
+	var js jsonSpec
+	if err := json.Unmarshal(data, &js); err != nil {
+		return err
+	}
+	*s = GradientSpec{
+		Preset:           js.Preset,
+		Domain:           js.Domain,
+		Mode:             js.Mode,
+		Interpolation:    js.Interpolation,
+		HueInterpolation: js.Hue,
+		Ggr:              js.Ggr,
+		NaN:              (*Color)(js.NaN),
+		Under:            (*Color)(js.Under),
+		Over:             (*Color)(js.Over),
+	}
+	for _, c := range js.Colors {
+		s.Colors = append(s.Colors, Color(c))
+	}
+	return nil
+}
+
+func (s GradientSpec) MarshalText() ([]byte, error) {
+	if s.Preset != "" && s.NaN == nil && s.Under == nil && s.Over == nil {
+		return []byte(s.Preset), nil
+	}
+	return s.MarshalJSON()
+}
+
+func (s *GradientSpec) UnmarshalText(text []byte) error {
+	text = bytes.TrimSpace(text)
+	if len(text) == 0 {
+		return fmt.Errorf("invalid gradient spec: empty")
+	}
+	if text[0] == '{' {
+		return s.UnmarshalJSON(text)
+	}
+	*s = GradientSpec{Preset: string(text)}
+	return nil
+}
+
+// Color written as hex if it has 8-bit channels, as rgb() with percentages
+// otherwise, so it is read back exactly.
+type specColor Color
+
+func (c specColor) MarshalText() ([]byte, error) {
+	col := Color(c)
+	r, g, b, a := col.RGBA255()
+	if col.Clamp() == col && Rgb8(r, g, b, a) == col {
+		return []byte(col.HexString()), nil
+	}
+	pct := func(v float64) string {
+		return strconv.FormatFloat(v*100, 'f', -1, 64) + "%"
+	}
+	return []byte(fmt.Sprintf("rgb(%s %s %s / %s)", pct(col.R), pct(col.G), pct(col.B), pct(col.A))), nil
+}
+
+func (c *specColor) UnmarshalText(text []byte) error {
+	col, err := csscolorparser.Parse(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid color %q", text)
+	}
+	*c = specColor(col)
+	return nil
+}
+
+var blendModeNames = []string{"rgb", "linear-rgb", "lab", "oklab", "oklch", "lch", "hsl", "hsv", "hwb"}
+
+var interpolationNames = []string{"linear", "smoothstep", "catmull-rom", "basis"}
+
+var hueInterpolationNames = []string{"shorter", "longer", "increasing", "decreasing"}
+
+func marshalEnum(v int, names []string, kind string) ([]byte, error) {
+	if v < 0 || v >= len(names) {
+		return nil, fmt.Errorf("invalid %s %d", kind, v)
+	}
+	return []byte(names[v]), nil
+}
+
+func unmarshalEnum(text []byte, names []string, kind string) (int, error) {
+	s := strings.ToLower(strings.TrimSpace(string(text)))
+	for i, name := range names {
+		if s == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid %s %q", kind, text)
+}
+
+// Text form is "rgb", "linear-rgb", "lab", "oklab", "oklch", "lch", "hsl",
+// "hsv" or "hwb"
+func (b BlendMode) MarshalText() ([]byte, error) {
+	return marshalEnum(int(b), blendModeNames, "blend mode")
+}
+
+func (b *BlendMode) UnmarshalText(text []byte) error {
+	v, err := unmarshalEnum(text, blendModeNames, "blend mode")
+	*b = BlendMode(v)
+	return err
+}
+
+// Text form is "linear", "smoothstep", "catmull-rom" or "basis"
+func (i Interpolation) MarshalText() ([]byte, error) {
+	return marshalEnum(int(i), interpolationNames, "interpolation")
+}
+
+func (i *Interpolation) UnmarshalText(text []byte) error {
+	v, err := unmarshalEnum(text, interpolationNames, "interpolation")
+	*i = Interpolation(v)
+	return err
+}
+
+// Text form is "shorter", "longer", "increasing" or "decreasing"
+func (h HueInterpolation) MarshalText() ([]byte, error) {
+	return marshalEnum(int(h), hueInterpolationNames, "hue interpolation")
+}
+
+func (h *HueInterpolation) UnmarshalText(text []byte) error {
+	v, err := unmarshalEnum(text, hueInterpolationNames, "hue interpolation")
+	*h = HueInterpolation(v)
+	return err
+}
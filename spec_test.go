@@ -0,0 +1,152 @@
+package colorgrad
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func Test_GradientSpec(t *testing.T) {
+	// Builder
+	grad, err := NewGradient().
+		HtmlColors("#f00", "gold", "#1e90ff80").
+		Colors(Rgb(0.1234567, 0.5, 1, 1)).
+		Domain(-1, 0, 3, 10).
+		Mode(BlendOklch).
+		Interpolation(InterpolationCatmullRom).
+		HueInterpolation(HueLonger).
+		NaNColor(Rgb(0, 0, 0, 0)).
+		Build()
+	test(t, err, nil)
+
+	spec, ok := grad.Spec()
+	testTrue(t, ok)
+	test(t, len(spec.Colors), 4)
+	testSlice(t, spec.Domain, []float64{-1, 0, 3, 10})
+	test(t, spec.Mode, BlendOklch)
+	test(t, spec.Interpolation, InterpolationCatmullRom)
+	test(t, spec.HueInterpolation, HueLonger)
+	testTrue(t, spec.Under == nil && spec.Over == nil)
+
+	data, err := json.Marshal(spec)
+	test(t, err, nil)
+	test(t, string(data), `{"colors":["#ff0000","#ffd700","#1e90ff80","rgb(12.34567% 50% 100% / 100%)"],"domain":[-1,0,3,10],"mode":"oklch","interpolation":"catmull-rom","hue":"longer","nan":"#00000000"}`)
+
+	var spec2 GradientSpec
+	err = json.Unmarshal(data, &spec2)
+	test(t, err, nil)
+	test(t, spec2.Colors[3], Rgb(0.1234567, 0.5, 1, 1))
+	grad2, err := spec2.Build()
+	test(t, err, nil)
+	testSlice(t, colors2hex(grad2.Colors(30)), colors2hex(grad.Colors(30)))
+	test(t, *grad2.NaN, Rgb(0, 0, 0, 0))
+
+	// Preset
+	grad = Viridis()
+	over := Rgb(1, 1, 1, 1)
+	grad.Over = &over
+	spec, ok = grad.Spec()
+	testTrue(t, ok)
+	test(t, spec.Preset, "Viridis")
+	test(t, *spec.Over, over)
+
+	data, err = json.Marshal(spec)
+	test(t, err, nil)
+	test(t, string(data), `{"preset":"Viridis","over":"#ffffff"}`)
+	text, err := spec.MarshalText()
+	test(t, err, nil)
+	test(t, string(text), string(data))
+
+	spec, _ = Turbo().Spec()
+	text, err = spec.MarshalText()
+	test(t, err, nil)
+	test(t, string(text), "Turbo")
+
+	for _, name := range []string{"Sinebow", "Turbo", "Cividis", "CubehelixDefault", "Warm", "Cool", "Rainbow", "RdBu", "YlOrRd"} {
+		grad, err = GradientSpec{Preset: name}.Build()
+		test(t, err, nil)
+		spec, ok = grad.Spec()
+		testTrue(t, ok)
+		test(t, spec.Preset, name)
+	}
+
+	// Preset name as a JSON string
+	var cfg struct {
+		Gradient GradientSpec
+	}
+	err = json.Unmarshal([]byte(`{"gradient": "Magma"}`), &cfg)
+	test(t, err, nil)
+	test(t, cfg.Gradient.Preset, "Magma")
+	grad, err = cfg.Gradient.Build()
+	test(t, err, nil)
+	testSlice(t, colors2hex(grad.Colors(10)), colors2hex(Magma().Colors(10)))
+
+	// GIMP gradient
+	ggr := "GIMP Gradient\nName: Test\n2\n0 0.25 0.5 1 0 0 1 0 0 0 1 1 0 0 0\n0.5 0.75 1 0 0 1 1 0 0 1 1 0 2 1 3"
+	grad, _, err = ParseGgr(strings.NewReader(ggr), Rgb(1, 1, 0, 1), Rgb(0, 1, 1, 1))
+	test(t, err, nil)
+	spec, ok = grad.Spec()
+	testTrue(t, ok)
+	testTrue(t, strings.HasPrefix(spec.Ggr, "GIMP Gradient\nName: Test\n2\n"))
+	data, err = json.Marshal(spec)
+	test(t, err, nil)
+	spec2 = GradientSpec{}
+	err = json.Unmarshal(data, &spec2)
+	test(t, err, nil)
+	grad2, err = spec2.Build()
+	test(t, err, nil)
+	testSlice(t, colors2hex(grad2.Colors(30)), colors2hex(grad.Colors(30)))
+
+	// Derived gradients
+	_, ok = Viridis().Reverse().Spec()
+	testTrue(t, !ok)
+	_, ok = Viridis().Sharp(5, 0).Spec()
+	testTrue(t, !ok)
+	_, ok = Viridis().Lut(16, false).Spec()
+	testTrue(t, !ok)
+}
+
+func Test_GradientSpecErrors(t *testing.T) {
+	_, err := GradientSpec{Preset: "Foo"}.Build()
+	test(t, err.Error(), `unknown preset "Foo"`)
+
+	_, err = GradientSpec{Preset: "Viridis", Colors: []Color{{}}}.Build()
+	testTrue(t, err != nil)
+
+	_, err = GradientSpec{Colors: []Color{{}, {}}, Domain: []float64{1, 0}}.Build()
+	test(t, err.Error(), "invalid domain")
+
+	var spec GradientSpec
+	data := []string{
+		`{"mode": "cmyk"}`,
+		`{"interpolation": "cubic"}`,
+		`{"hue": "shortest"}`,
+		`{"colors": ["#ff0000", "#zzz"]}`,
+		`{"preset": 1}`,
+		`""`,
+	}
+	for _, s := range data {
+		testTrue(t, json.Unmarshal([]byte(s), &spec) != nil)
+	}
+
+	err = spec.UnmarshalText([]byte(" Inferno "))
+	test(t, err, nil)
+	test(t, spec.Preset, "Inferno")
+}
+
+func Test_EnumText(t *testing.T) {
+	for _, mode := range []BlendMode{BlendRgb, BlendLinearRgb, BlendLab, BlendOklab, BlendOklch, BlendLch, BlendHsl, BlendHsv, BlendHwb} {
+		text, err := mode.MarshalText()
+		test(t, err, nil)
+		var m BlendMode
+		test(t, m.UnmarshalText(text), nil)
+		test(t, m, mode)
+	}
+
+	var i Interpolation
+	test(t, i.UnmarshalText([]byte("Catmull-Rom")), nil)
+	test(t, i, InterpolationCatmullRom)
+
+	_, err := BlendMode(99).MarshalText()
+	test(t, err.Error(), "invalid blend mode 99")
+}
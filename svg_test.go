@@ -0,0 +1,95 @@
+package colorgrad
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_ParseSvgGradient(t *testing.T) {
+	svg := `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink">
+  <defs>
+    <linearGradient id="base">
+      <stop offset="0" stop-color="red"/>
+      <stop offset="50%" stop-color="#00ff00" stop-opacity="0.5"/>
+      <stop offset="1" style="stop-color: blue; stop-opacity: 1" stop-color="gold"/>
+    </linearGradient>
+    <radialGradient id="ref" xlink:href="#base" cx="0.5"/>
+    <linearGradient id="ref2" href="#ref"/>
+    <linearGradient id="unordered">
+      <stop offset="0.7" stop-color="black"/>
+      <stop offset="0.2" stop-color="white"/>
+    </linearGradient>
+    <linearGradient id="loop" href="#loop"/>
+  </defs>
+</svg>`
+
+	for _, id := range []string{"", "base", "ref", "ref2"} {
+		gb, err := ParseSvgGradient(strings.NewReader(svg), id)
+		test(t, err, nil)
+		grad, err := gb.Build()
+		test(t, err, nil)
+		testSlice(t, colors2hex(grad.Colors(3)), []string{"#ff0000", "#00ff0080", "#0000ff"})
+	}
+
+	gb, err := ParseSvgGradient(strings.NewReader(svg), "unordered")
+	test(t, err, nil)
+	grad, err := gb.Build()
+	test(t, err, nil)
+	testSlice(t, *gb.GetPositions(), []float64{0, 0.7, 0.7, 1})
+	test(t, grad.At(0.5).HexString(), "#000000")
+	test(t, grad.At(0.8).HexString(), "#ffffff")
+
+	// Invalid
+	for _, id := range []string{"loop", "none"} {
+		_, err = ParseSvgGradient(strings.NewReader(svg), id)
+		testTrue(t, err != nil)
+	}
+
+	_, err = ParseSvgGradient(strings.NewReader(`<svg><linearGradient><stop offset="x"/></linearGradient></svg>`), "")
+	testTrue(t, err != nil)
+
+	_, err = ParseSvgGradient(strings.NewReader(`<svg><linearGradient><stop stop-color="reds"/></linearGradient></svg>`), "")
+	testTrue(t, err != nil)
+
+	_, err = ParseSvgGradient(strings.NewReader(`<svg></svg>`), "")
+	testTrue(t, err != nil)
+}
+
+func Test_WriteSvgGradient(t *testing.T) {
+	var sb strings.Builder
+
+	grad, _ := NewGradient().
+		HtmlColors("#f00", "#00ff0080", "#00f").
+		Domain(-1, 0, 3).
+		Build()
+
+	err := WriteSvgGradient(&sb, grad, SvgOptions{ID: "a&b"})
+	test(t, err, nil)
+	test(t, sb.String(), `<linearGradient id="a&amp;b">
+  <stop offset="0" stop-color="#ff0000"/>
+  <stop offset="0.25" stop-color="#00ff00" stop-opacity="0.502"/>
+  <stop offset="1" stop-color="#0000ff"/>
+</linearGradient>
+`)
+
+	sb.Reset()
+	err = WriteSvgGradient(&sb, Viridis(), SvgOptions{Radial: true, Stops: 3})
+	test(t, err, nil)
+	test(t, sb.String(), `<radialGradient>
+  <stop offset="0" stop-color="#440154"/>
+  <stop offset="0.5" stop-color="#27838e"/>
+  <stop offset="1" stop-color="#fee825"/>
+</radialGradient>
+`)
+
+	// Round trip
+	sb.Reset()
+	grad = Rainbow().Sharp(5, 0)
+	err = WriteSvgGradient(&sb, grad, SvgOptions{})
+	test(t, err, nil)
+	gb, err := ParseSvgGradient(strings.NewReader(sb.String()), "")
+	test(t, err, nil)
+	grad2, err := gb.Build()
+	test(t, err, nil)
+	testSlice(t, colors2hex(grad2.Colors(23)), colors2hex(grad.Colors(23)))
+}
@@ -0,0 +1,119 @@
+package colorgrad
+
+import (
+	"math"
+)
+
+type UniformMetric int
+
+const (
+	// Euclidean distance in Oklab, or the change in Oklab L
+	UniformOklab UniformMetric = iota
+	// CIEDE2000 difference, or the change in CIE L*
+	UniformCIEDE2000
+)
+
+func (m UniformMetric) String() string {
+	switch m {
+	case UniformOklab:
+		return "UniformOklab"
+	case UniformCIEDE2000:
+		return "UniformCIEDE2000"
+	}
+	return ""
+}
+
+type UniformOptions struct {
+	// Color difference made equal along the domain. Default is Oklab.
+	Metric UniformMetric
+	// Only make the change in lightness equal, ignoring hue and chroma
+	Lightness bool
+	// Number of samples measuring the gradient. Default is 256.
+	Samples int
+}
+
+// Positions of the samples in the original gradient and the normalized
+// cumulative color difference up to them, both ascending
+type uniformGradient struct {
+	core      GradientCore
+	min       float64
+	max       float64
+	positions []float64
+	lengths   []float64
+}
+
+func (ug uniformGradient) At(t float64) Color {
+	if math.IsNaN(t) {
+		return ug.core.At(t)
+	}
+	u := clamp01(norm(t, ug.min, ug.max))
+	i := searchStop(ug.lengths, u)
+	l0, l1 := ug.lengths[i-1], ug.lengths[i]
+	p0, p1 := ug.positions[i-1], ug.positions[i]
+	if l1 > l0 {
+		p0 += (u - l0) / (l1 - l0) * (p1 - p0)
+	}
+	return ug.core.At(p0)
+}
+
+// Return the gradient with its domain warped so equal steps in position are
+// equal color differences, e.g. to turn a hand-made gradient into a
+// perceptually uniform colormap. The domain, colors at the ends, NaN, under
+// and over colors are kept. Differences are summed along the path of the
+// colors, and parts where the color doesn't change are skipped. Alpha is
+// ignored.
+func (g Gradient) Uniform(opts UniformOptions) Gradient {
+	if opts.Samples < 2 {
+		opts.Samples = 256
+	}
+
+	positions := linspace(g.Min, g.Max, uint(opts.Samples))
+	lengths := make([]float64, len(positions))
+	prev := uniformCoords(g.Core.At(positions[0]), opts)
+
+	for i := 1; i < len(positions); i++ {
+		cur := uniformCoords(g.Core.At(positions[i]), opts)
+		lengths[i] = lengths[i-1] + uniformDistance(prev, cur, opts)
+		prev = cur
+	}
+
+	total := lengths[len(lengths)-1]
+	if total == 0 || math.IsNaN(total) {
+		return g
+	}
+	for i := range lengths {
+		lengths[i] /= total
+	}
+
+	return Gradient{
+		Core: uniformGradient{
+			core:      g.Core,
+			min:       g.Min,
+			max:       g.Max,
+			positions: positions,
+			lengths:   lengths,
+		},
+		Min:   g.Min,
+		Max:   g.Max,
+		NaN:   g.NaN,
+		Under: g.Under,
+		Over:  g.Over,
+	}
+}
+
+func uniformCoords(col Color, opts UniformOptions) [4]float64 {
+	if opts.Metric == UniformCIEDE2000 {
+		return col2lab(col)
+	}
+	return col2oklab(col)
+}
+
+func uniformDistance(a, b [4]float64, opts UniformOptions) float64 {
+	switch {
+	case opts.Lightness:
+		return math.Abs(b[0] - a[0])
+	case opts.Metric == UniformCIEDE2000:
+		return deltaE2000(a, b)
+	}
+	return math.Sqrt(sq(b[0]-a[0]) + sq(b[1]-a[1]) + sq(b[2]-a[2]))
+}
@@ -0,0 +1,80 @@
+package colorgrad
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_Uniform(t *testing.T) {
+	// Half of the lightness in the first tenth of the domain
+	grad, _ := NewGradient().
+		HtmlColors("#000", "#777", "#fff").
+		Domain(0, 0.1, 1).
+		Mode(BlendOklab).
+		Build()
+
+	steps := func(g Gradient, dist func(a, b Color) float64) []float64 {
+		colors := g.Colors(11)
+		res := make([]float64, 10)
+		for i := range res {
+			res[i] = dist(colors[i], colors[i+1])
+		}
+		return res
+	}
+	uniform := func(s []float64) bool {
+		mean := 0.0
+		for _, v := range s {
+			mean += v / float64(len(s))
+		}
+		for _, v := range s {
+			if math.Abs(v/mean-1) > 0.02 {
+				return false
+			}
+		}
+		return true
+	}
+	lightness := func(a, b Color) float64 {
+		return math.Abs(col2oklab(a)[0] - col2oklab(b)[0])
+	}
+
+	testTrue(t, !uniform(steps(grad, DeltaEOk)))
+
+	ug := grad.Uniform(UniformOptions{})
+	testTrue(t, uniform(steps(ug, DeltaEOk)))
+	test(t, ug.Min, 0.0)
+	test(t, ug.Max, 1.0)
+	test(t, ug.At(0).HexString(), "#000000")
+	test(t, ug.At(1).HexString(), "#ffffff")
+	test(t, ug.At(-1).HexString(), "#000000")
+	test(t, ug.At(2).HexString(), "#ffffff")
+
+	ug = grad.Uniform(UniformOptions{Metric: UniformCIEDE2000, Samples: 512})
+	testTrue(t, uniform(steps(ug, DeltaE2000)))
+
+	ug = grad.Uniform(UniformOptions{Lightness: true})
+	testTrue(t, uniform(steps(ug, lightness)))
+
+	// Domain and special colors are kept
+	grad, _ = NewGradient().
+		HtmlColors("#f00", "#800", "#000").
+		Domain(-5, -4, 5).
+		Build()
+	grad.NaN = &Color{R: 0.5, A: 1}
+	ug = grad.Uniform(UniformOptions{})
+	testTrue(t, uniform(steps(ug, DeltaEOk)))
+	test(t, ug.Min, -5.0)
+	test(t, ug.Max, 5.0)
+	test(t, ug.At(math.NaN()), *grad.NaN)
+	test(t, ug.At(-5).HexString(), "#ff0000")
+	test(t, ug.At(5).HexString(), "#000000")
+
+	// No change in color
+	grad, _ = NewGradient().HtmlColors("#f00", "#f00").Build()
+	ug = grad.Uniform(UniformOptions{})
+	test(t, ug.At(0.5).HexString(), "#ff0000")
+
+	// No change in lightness
+	grad, _ = NewGradient().HtmlColors("#777", "#777").Build()
+	ug = grad.Uniform(UniformOptions{Lightness: true})
+	test(t, ug.At(0.5).HexString(), "#777777")
+}
@@ -16,6 +16,10 @@ func linspace(min, max float64, n uint) []float64 {
 	for i := range res {
 		res[i] = (min + (float64(i)*d)/l)
 	}
+	// Rounding may overshoot max
+	if n > 0 {
+		res[n-1] = max
+	}
 	return res
 }
 
@@ -37,6 +41,18 @@ func parseFloat(s string) (float64, bool) {
 	return f, err == nil
 }
 
+// Format float using at most prec decimal places, without trailing zeros
+func formatFloat(v float64, prec int) string {
+	s := strconv.FormatFloat(v, 'f', prec, 64)
+	if strings.ContainsRune(s, '.') {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
+
 func toLinear(x float64) float64 {
 	if x >= 0.04045 {
 		return math.Pow((x+0.055)/1.055, 2.4)
@@ -53,17 +69,57 @@ func col2linearRgb(col Color) [4]float64 {
 	}
 }
 
-func col2oklab(col Color) [4]float64 {
-	arr := col2linearRgb(col)
-	l := math.Cbrt(0.4121656120*arr[0] + 0.5362752080*arr[1] + 0.0514575653*arr[2])
-	m := math.Cbrt(0.2118591070*arr[0] + 0.6807189584*arr[1] + 0.1074065790*arr[2])
-	s := math.Cbrt(0.0883097947*arr[0] + 0.2818474174*arr[1] + 0.6302613616*arr[2])
-	return [4]float64{
-		0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
-		1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
-		0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
-		col.A,
+// Row-major 3x3 matrix
+type mat3 [9]float64
+
+func (m *mat3) mul(v [4]float64) [3]float64 {
+	return [3]float64{
+		m[0]*v[0] + m[1]*v[1] + m[2]*v[2],
+		m[3]*v[0] + m[4]*v[1] + m[5]*v[2],
+		m[6]*v[0] + m[7]*v[1] + m[8]*v[2],
+	}
+}
+
+func (m *mat3) inverse() mat3 {
+	c := mat3{
+		m[4]*m[8] - m[5]*m[7], m[2]*m[7] - m[1]*m[8], m[1]*m[5] - m[2]*m[4],
+		m[5]*m[6] - m[3]*m[8], m[0]*m[8] - m[2]*m[6], m[2]*m[3] - m[0]*m[5],
+		m[3]*m[7] - m[4]*m[6], m[1]*m[6] - m[0]*m[7], m[0]*m[4] - m[1]*m[3],
+	}
+	det := m[0]*c[0] + m[1]*c[3] + m[2]*c[6]
+	for i := range c {
+		c[i] /= det
+	}
+	return c
+}
+
+// Linear RGB to LMS, and cube root of LMS to Oklab
+var (
+	oklabM1 = mat3{
+		0.4121656120, 0.5362752080, 0.0514575653,
+		0.2118591070, 0.6807189584, 0.1074065790,
+		0.0883097947, 0.2818474174, 0.6302613616,
 	}
+	oklabM2 = mat3{
+		0.2104542553, 0.7936177850, -0.0040720468,
+		1.9779984951, -2.4285922050, 0.4505937099,
+		0.0259040371, 0.7827717662, -0.8086757660,
+	}
+	// Exact inverses, so that converting to Oklab and back is lossless
+	oklabM1Inv = oklabM1.inverse()
+	oklabM2Inv = oklabM2.inverse()
+)
+
+func col2oklab(col Color) [4]float64 {
+	lms := oklabM1.mul(col2linearRgb(col))
+	lab := oklabM2.mul([4]float64{math.Cbrt(lms[0]), math.Cbrt(lms[1]), math.Cbrt(lms[2])})
+	return [4]float64{lab[0], lab[1], lab[2], col.A}
+}
+
+// Linear RGB of an Oklab color, the inverse of col2oklab
+func oklab2linearRgb(l, a, b float64) [3]float64 {
+	lms := oklabM2Inv.mul([4]float64{l, a, b})
+	return oklabM1Inv.mul([4]float64{lms[0] * lms[0] * lms[0], lms[1] * lms[1] * lms[1], lms[2] * lms[2] * lms[2]})
 }
 
 func col2hsv(col Color) [4]float64 {
@@ -101,7 +157,77 @@ func normalizeAngle(t float64) float64 {
 	return t
 }
 
-func convertColors(colorsIn []Color, mode BlendMode) [][4]float64 {
+func col2hsl(col Color) [4]float64 {
+	max := math.Max(col.R, math.Max(col.G, col.B))
+	min := math.Min(col.R, math.Min(col.G, col.B))
+	l := (max + min) / 2
+	d := max - min
+
+	if math.Abs(d) < epsilon {
+		return [4]float64{0, 0, l, col.A}
+	}
+
+	var s float64
+	if l < 0.5 {
+		s = d / (max + min)
+	} else {
+		s = d / (2 - max - min)
+	}
+
+	hsv := col2hsv(col)
+	return [4]float64{hsv[0], s, l, col.A}
+}
+
+func col2hwb(col Color) [4]float64 {
+	hsv := col2hsv(col)
+	return [4]float64{hsv[0], (1 - hsv[1]) * hsv[2], 1 - hsv[2], col.A}
+}
+
+func col2lch(col Color) [4]float64 {
+	lab := col2lab(col)
+	return lab2lch(lab)
+}
+
+func col2oklch(col Color) [4]float64 {
+	lab := col2oklab(col)
+	return lab2lch(lab)
+}
+
+// Convert Lab or Oklab to its cylindrical form, hue in degrees.
+func lab2lch(lab [4]float64) [4]float64 {
+	c := math.Sqrt(lab[1]*lab[1] + lab[2]*lab[2])
+	h := normalizeAngle(math.Atan2(lab[2], lab[1]) / deg2rad)
+	return [4]float64{lab[0], c, h, lab[3]}
+}
+
+// Index of the hue component for polar blend modes, -1 for the others.
+func hueIndex(mode BlendMode) int {
+	switch mode {
+	case BlendOklch, BlendLch:
+		return 2
+	case BlendHsl, BlendHsv, BlendHwb:
+		return 0
+	}
+	return -1
+}
+
+// Reports whether the hue of a color in a polar blend mode is powerless,
+// i.e. the color is achromatic.
+func isPowerlessHue(arr [4]float64, mode BlendMode) bool {
+	switch mode {
+	case BlendOklch:
+		return arr[1] < 4e-4
+	case BlendLch:
+		return arr[1] < 1e-2
+	case BlendHsl, BlendHsv:
+		return arr[1] < epsilon
+	case BlendHwb:
+		return arr[1]+arr[2] >= 1-epsilon
+	}
+	return false
+}
+
+func convertColors(colorsIn []Color, mode BlendMode, hue HueInterpolation) [][4]float64 {
 	colors := make([][4]float64, len(colorsIn))
 	for i, col := range colorsIn {
 		switch mode {
@@ -113,11 +239,97 @@ func convertColors(colorsIn []Color, mode BlendMode) [][4]float64 {
 			colors[i] = col2lab(col)
 		case BlendOklab:
 			colors[i] = col2oklab(col)
+		case BlendOklch:
+			colors[i] = col2oklch(col)
+		case BlendLch:
+			colors[i] = col2lch(col)
+		case BlendHsl:
+			colors[i] = col2hsl(col)
+		case BlendHsv:
+			colors[i] = col2hsv(col)
+		case BlendHwb:
+			colors[i] = col2hwb(col)
 		}
 	}
+	if idx := hueIndex(mode); idx >= 0 {
+		fixupHues(colors, mode, idx, hue)
+	}
 	return colors
 }
 
+// Unwrap the hue of each color relative to the previous one, so that
+// interpolating the hue component as a plain number follows the requested
+// path around the hue circle. Powerless hues take the hue of the nearest
+// color which has one.
+func fixupHues(colors [][4]float64, mode BlendMode, idx int, hue HueInterpolation) {
+	powerless := make([]bool, len(colors))
+	ref := -1
+	for i, arr := range colors {
+		powerless[i] = isPowerlessHue(arr, mode)
+		if !powerless[i] && ref < 0 {
+			ref = i
+		}
+	}
+	if ref < 0 {
+		return
+	}
+	for i := range colors {
+		if powerless[i] {
+			if i > 0 {
+				colors[i][idx] = colors[i-1][idx]
+			} else {
+				colors[i][idx] = colors[ref][idx]
+			}
+			continue
+		}
+		if i == 0 {
+			continue
+		}
+		prev := colors[i-1][idx]
+		d := normalizeAngle(colors[i][idx] - prev)
+		switch hue {
+		case HueShorter:
+			if d > 180 {
+				d -= 360
+			}
+		case HueLonger:
+			if d > 0 && d < 180 {
+				d -= 360
+			}
+		case HueDecreasing:
+			if d > 0 {
+				d -= 360
+			}
+		}
+		colors[i][idx] = prev + d
+	}
+}
+
+// Convert a color from the blend mode color space back to Color.
+func colorFromSpace(mode BlendMode, a, b, c, d float64) Color {
+	switch mode {
+	case BlendRgb:
+		return Color{R: a, G: b, B: c, A: d}
+	case BlendLinearRgb:
+		return LinearRgb(a, b, c, d)
+	case BlendLab:
+		return Lab(a, b, c, d).Clamp()
+	case BlendOklab:
+		return Oklab(a, b, c, d).Clamp()
+	case BlendOklch:
+		return Oklch(a, b, c*deg2rad, d).Clamp()
+	case BlendLch:
+		return Lch(a, b, c*deg2rad, d).Clamp()
+	case BlendHsl:
+		return Hsl(a, b, c, d)
+	case BlendHsv:
+		return Hsv(a, b, c, d)
+	case BlendHwb:
+		return Hwb(a, b, c, d)
+	}
+	return Color{}
+}
+
 func linearInterpolate(a, b [4]float64, t float64) (i, j, k, l float64) {
 	i = a[0] + t*(b[0]-a[0])
 	j = a[1] + t*(b[1]-a[1])
@@ -180,3 +392,23 @@ func col2lab(col Color) [4]float64 {
 	l := xyzToLab(x[0], x[1], x[2])
 	return [4]float64{l[0], l[1], l[2], col.A}
 }
+
+// Color stops of gradients which are piecewise linear in RGB: linear
+// gradients blended in RGB and sharp gradients. Positions are in the
+// gradient domain.
+func exactStops(g Gradient) ([]Color, []float64, bool) {
+	switch core := g.Core.(type) {
+	case linearGradient:
+		if core.mode != BlendRgb {
+			return nil, nil, false
+		}
+		colors := make([]Color, len(core.colors))
+		for i, c := range core.colors {
+			colors[i] = Color{R: c[0], G: c[1], B: c[2], A: c[3]}
+		}
+		return colors, core.positions, true
+	case sharpGradient:
+		return core.colors, core.positions, true
+	}
+	return nil, nil, false
+}
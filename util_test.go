@@ -78,17 +78,17 @@ func Test_Utils(t *testing.T) {
 		Hsl(120, 0.3, 0.2, 1),
 	}
 
-	for i, arr := range convertColors(colors, BlendRgb) {
+	for i, arr := range convertColors(colors, BlendRgb, HueShorter) {
 		col := Rgb(spreadF64(arr))
 		test(t, colors[i].HexString(), col.HexString())
 	}
 
-	for i, arr := range convertColors(colors, BlendLinearRgb) {
+	for i, arr := range convertColors(colors, BlendLinearRgb, HueShorter) {
 		col := LinearRgb(spreadF64(arr))
 		test(t, colors[i].HexString(), col.HexString())
 	}
 
-	/*for i, arr := range convertColors(colors, BlendOklab) {
+	/*for i, arr := range convertColors(colors, BlendOklab, HueShorter) {
 		col := Oklab(spreadF64(arr))
 		test(t, colors[i].HexString(), col.HexString())
 	}*/
@@ -153,6 +153,40 @@ func Test_Lab(t *testing.T) {
 	}
 }
 
+func Test_PolarConversion(t *testing.T) {
+	testData := []string{
+		"#000000",
+		"#ffffff",
+		"#ff0000",
+		"#123abc",
+		"#bad455",
+		"#abc5679b",
+	}
+	for _, s := range testData {
+		c, err := csscolorparser.Parse(s)
+		test(t, err, nil)
+
+		for _, mode := range []BlendMode{BlendOklch, BlendLch, BlendHsl, BlendHsv, BlendHwb} {
+			arr := convertColors([]Color{c}, mode, HueShorter)[0]
+			test(t, colorFromSpace(mode, arr[0], arr[1], arr[2], arr[3]).HexString(), s)
+		}
+	}
+
+	// Hue unwrapping
+	colors := []Color{Hsv(350, 1, 1, 1), Hsv(10, 1, 1, 1), Hsv(0, 0, 1, 1), Hsv(200, 1, 1, 1)}
+	hues := func(hue HueInterpolation) []float64 {
+		res := []float64{}
+		for _, arr := range convertColors(colors, BlendHsv, hue) {
+			res = append(res, math.Round(arr[0]))
+		}
+		return res
+	}
+	testSlice(t, hues(HueShorter), []float64{350, 370, 370, 200})
+	testSlice(t, hues(HueLonger), []float64{350, 10, 10, 200})
+	testSlice(t, hues(HueIncreasing), []float64{350, 370, 370, 560})
+	testSlice(t, hues(HueDecreasing), []float64{350, 10, 10, -160})
+}
+
 // --- Helper functions
 
 func test(t *testing.T, a, b any) {